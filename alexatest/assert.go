@@ -0,0 +1,158 @@
+package alexatest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda"
+)
+
+// Assertion checks a response, returning an error describing the mismatch.
+type Assertion func(resp *alexa.ResponseEnvelope) error
+
+// Speech returns the text or SSML of the output speech of the response.
+func Speech(resp *alexa.ResponseEnvelope) string {
+	return speech(resp.Response.OutputSpeech)
+}
+
+// Reprompt returns the text or SSML of the reprompt of the response.
+func Reprompt(resp *alexa.ResponseEnvelope) string {
+	if resp.Response.Reprompt == nil {
+		return ""
+	}
+
+	return speech(resp.Response.Reprompt.OutputSpeech)
+}
+
+func speech(s *alexa.OutputSpeech) string {
+	switch {
+	case s == nil:
+		return ""
+	case s.Type == "SSML":
+		return s.SSML
+	default:
+		return s.Text
+	}
+}
+
+// SpeechContains asserts the output speech contains the text.
+func SpeechContains(text string) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		if got := Speech(resp); !strings.Contains(got, text) {
+			return fmt.Errorf("speech %q does not contain %q", got, text)
+		}
+
+		return nil
+	}
+}
+
+// RepromptContains asserts the reprompt contains the text.
+func RepromptContains(text string) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		if got := Reprompt(resp); !strings.Contains(got, text) {
+			return fmt.Errorf("reprompt %q does not contain %q", got, text)
+		}
+
+		return nil
+	}
+}
+
+// CardTitle asserts the title of the card.
+func CardTitle(title string) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		card := resp.Response.Card
+		if card == nil {
+			return fmt.Errorf("no card, want title %q", title)
+		}
+
+		if card.Title != title {
+			return fmt.Errorf("card title %q, want %q", card.Title, title)
+		}
+
+		return nil
+	}
+}
+
+// CardContains asserts the content of a simple or the text of a standard card contains the text.
+func CardContains(text string) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		card := resp.Response.Card
+		if card == nil {
+			return fmt.Errorf("no card, want text %q", text)
+		}
+
+		if got := card.Content + card.Text; !strings.Contains(got, text) {
+			return fmt.Errorf("card %q does not contain %q", got, text)
+		}
+
+		return nil
+	}
+}
+
+// HasDirective asserts the response has a directive of the type.
+func HasDirective(typ alexa.DirectiveType) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		for _, d := range resp.Response.Directives {
+			if d.Type == typ {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no directive %s", typ)
+	}
+}
+
+// ElicitsSlot asserts the response elicits the slot.
+func ElicitsSlot(slot string) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		for _, d := range resp.Response.Directives {
+			if d.Type == alexa.DirectiveTypeDialogElicitSlot && d.SlotToElicit == slot {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("slot %s not elicited", slot)
+	}
+}
+
+// SessionEnded asserts the response ends the session.
+func SessionEnded() Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		if !resp.Response.ShouldEndSession {
+			return errors.New("session not ended")
+		}
+
+		return nil
+	}
+}
+
+// SessionOpen asserts the response keeps the session open.
+func SessionOpen() Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		if resp.Response.ShouldEndSession {
+			return errors.New("session ended")
+		}
+
+		return nil
+	}
+}
+
+// SessionAttribute asserts the value of the session attribute.
+//
+// Keep in mind that numbers in session attributes are decoded as float64.
+func SessionAttribute(key string, value interface{}) Assertion {
+	return func(resp *alexa.ResponseEnvelope) error {
+		got, ok := resp.SessionAttributes[key]
+		if !ok {
+			return fmt.Errorf("no session attribute %s", key)
+		}
+
+		if !reflect.DeepEqual(got, value) {
+			return fmt.Errorf("session attribute %s = %v, want %v", key, got, value)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,341 @@
+package alexatest
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/skill"
+)
+
+// ErrNoMatch is returned if an utterance matches no intent of the model.
+var ErrNoMatch = errors.New("alexatest: utterance matches no intent")
+
+// builtinSamples are samples of built-in intents without samples in the model, by language.
+var builtinSamples = map[string]map[string][]string{
+	"en": {
+		alexa.HelpIntent:   {"help", "help me", "what can I do"},
+		alexa.CancelIntent: {"cancel", "never mind"},
+		alexa.StopIntent:   {"stop", "exit", "quit", "off", "halt"},
+		alexa.NextIntent:   {"next", "continue", "go on"},
+		alexa.RepeatIntent: {"repeat", "say that again", "again"},
+		"AMAZON.YesIntent": {"yes", "yeah", "sure"},
+		"AMAZON.NoIntent":  {"no", "nope"},
+	},
+	"de": {
+		alexa.HelpIntent:   {"hilfe", "hilf mir", "was kann ich tun"},
+		alexa.CancelIntent: {"abbrechen", "vergiss es"},
+		alexa.StopIntent:   {"stopp", "stop", "halt", "beenden", "aus"},
+		alexa.NextIntent:   {"weiter", "nächste"},
+		alexa.RepeatIntent: {"wiederholen", "nochmal", "noch einmal"},
+		"AMAZON.YesIntent": {"ja", "jawohl", "klar"},
+		"AMAZON.NoIntent":  {"nein", "nö"},
+	},
+	"fr": {
+		alexa.HelpIntent:   {"aide", "aide-moi", "que puis-je faire"},
+		alexa.CancelIntent: {"annuler", "laisse tomber"},
+		alexa.StopIntent:   {"stop", "arrête", "quitter"},
+		alexa.NextIntent:   {"suivant", "continue"},
+		alexa.RepeatIntent: {"répète", "encore"},
+		"AMAZON.YesIntent": {"oui", "ouais", "d'accord"},
+		"AMAZON.NoIntent":  {"non"},
+	},
+	"it": {
+		alexa.HelpIntent:   {"aiuto", "aiutami", "cosa posso fare"},
+		alexa.CancelIntent: {"annulla", "lascia perdere"},
+		alexa.StopIntent:   {"stop", "basta", "esci", "fermati"},
+		alexa.NextIntent:   {"avanti", "prossimo", "continua"},
+		alexa.RepeatIntent: {"ripeti", "di nuovo", "ancora"},
+		"AMAZON.YesIntent": {"sì", "certo", "va bene"},
+		"AMAZON.NoIntent":  {"no"},
+	},
+	"es": {
+		alexa.HelpIntent:   {"ayuda", "ayúdame", "qué puedo hacer"},
+		alexa.CancelIntent: {"cancelar", "cancela", "olvídalo"},
+		alexa.StopIntent:   {"para", "detente", "salir", "basta"},
+		alexa.NextIntent:   {"siguiente", "continúa"},
+		alexa.RepeatIntent: {"repite", "otra vez"},
+		"AMAZON.YesIntent": {"sí", "claro", "vale"},
+		"AMAZON.NoIntent":  {"no"},
+	},
+	"ja": {
+		alexa.HelpIntent:   {"ヘルプ", "助けて"},
+		alexa.CancelIntent: {"キャンセル"},
+		alexa.StopIntent:   {"ストップ", "止めて", "終了"},
+		alexa.NextIntent:   {"次", "次へ"},
+		alexa.RepeatIntent: {"もう一度", "繰り返して"},
+		"AMAZON.YesIntent": {"はい", "うん"},
+		"AMAZON.NoIntent":  {"いいえ", "いや"},
+	},
+}
+
+// MatcherConfig contains the options of a Matcher.
+type MatcherConfig struct {
+	Locale alexa.RequestLocale
+}
+
+// MatcherFunc defines the functions to be passed to NewMatcher.
+type MatcherFunc func(cfg *MatcherConfig)
+
+// WithMatcherLocale matches utterances in the language of the locale, defaults to DefaultLocale.
+//
+// The language selects the samples of built-in intents and the spelled-out numbers of AMAZON.NUMBER slots.
+func WithMatcherLocale(locale alexa.RequestLocale) MatcherFunc {
+	return func(cfg *MatcherConfig) {
+		cfg.Locale = locale
+	}
+}
+
+// token is a word or a {slot} of a sample.
+type token struct {
+	word string
+	slot string
+}
+
+type sample struct {
+	intent string
+	tokens []token
+}
+
+// Matcher matches utterances to intents and slots of an interaction model, offline and without Alexa.
+//
+// Samples are matched word by word ignoring case and punctuation, slots capture one or more words.
+// Slots of custom types are resolved against the values and synonyms of the type, samples of only slots
+// match only resolved values. AMAZON.NUMBER slots capture digits and spelled-out numbers of the language,
+// filled with digits like Alexa does. Utterances matching no sample are sent as AMAZON.FallbackIntent
+// if the model has it.
+type Matcher struct {
+	lang    string
+	samples []sample
+	slots   map[string]map[string]string // intent > slot > type
+	types   map[string]skill.ModelType
+}
+
+// NewMatcher returns a matcher of the interaction model, e.g. built with modelBuilder.BuildLocale.
+//
+// The model has no locale, pass the locale of the model with WithMatcherLocale.
+func NewMatcher(model *skill.Model, opts ...MatcherFunc) *Matcher {
+	cfg := MatcherConfig{Locale: DefaultLocale}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	lang, _, _ := strings.Cut(string(cfg.Locale), "-")
+
+	m := &Matcher{
+		lang:  lang,
+		slots: map[string]map[string]string{},
+		types: map[string]skill.ModelType{},
+	}
+
+	for _, t := range model.Model.Language.Types {
+		m.types[t.Name] = t
+	}
+
+	intents := append([]skill.ModelIntent(nil), model.Model.Language.Intents...)
+	sort.Slice(intents, func(i, j int) bool { return intents[i].Name < intents[j].Name })
+
+	for _, i := range intents {
+		m.slots[i.Name] = map[string]string{}
+		for _, s := range i.Slots {
+			m.slots[i.Name][s.Name] = s.Type
+		}
+
+		samples := i.Samples
+		if len(samples) == 0 {
+			samples = builtinSamples[lang][i.Name]
+		}
+
+		for _, s := range samples {
+			m.samples = append(m.samples, sample{intent: i.Name, tokens: tokenize(s)})
+		}
+	}
+
+	return m
+}
+
+func tokenize(s string) []token {
+	var tokens []token
+
+	for _, w := range words(s) {
+		if strings.HasPrefix(w, "{") && strings.HasSuffix(w, "}") {
+			tokens = append(tokens, token{slot: strings.Trim(w, "{}")})
+			continue
+		}
+
+		tokens = append(tokens, token{word: w})
+	}
+
+	return tokens
+}
+
+// words returns the lower case words of the text without punctuation, keeping {slots}.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("'{}_-", r)
+	})
+}
+
+// match is a sample matching an utterance.
+type match struct {
+	intent   string
+	slots    map[string]string
+	literals int
+	resolved int
+}
+
+func (a match) better(b match) bool {
+	if a.literals != b.literals {
+		return a.literals > b.literals
+	}
+
+	return a.resolved > b.resolved
+}
+
+// Match returns the intent of the utterance with the slots filled, or ErrNoMatch.
+//
+// Unfilled slots of the intent are included without value, like Alexa sends them.
+func (m *Matcher) Match(utterance string) (alexa.Intent, error) {
+	input := words(utterance)
+
+	var (
+		best  match
+		found bool
+	)
+
+	for _, s := range m.samples {
+		slots := map[string]string{}
+		if !m.matchTokens(s.intent, s.tokens, input, slots) {
+			continue
+		}
+
+		c := match{intent: s.intent, slots: slots}
+		for _, t := range s.tokens {
+			if t.word != "" {
+				c.literals++
+			}
+		}
+
+		for name, value := range slots {
+			if _, _, ok := m.resolve(m.slots[s.intent][name], value); ok {
+				c.resolved++
+			}
+		}
+
+		// samples of only slots would match anything
+		if c.literals == 0 && c.resolved < len(slots) {
+			continue
+		}
+
+		if !found || c.better(best) {
+			best, found = c, true
+		}
+	}
+
+	if !found {
+		if _, ok := m.slots[alexa.FallbackIntent]; ok {
+			return m.intent(match{intent: alexa.FallbackIntent}), nil
+		}
+
+		return alexa.Intent{}, ErrNoMatch
+	}
+
+	return m.intent(best), nil
+}
+
+// matchTokens matches the tokens to the words, capturing slot values.
+func (m *Matcher) matchTokens(intent string, tokens []token, input []string, slots map[string]string) bool {
+	if len(tokens) == 0 {
+		return len(input) == 0
+	}
+
+	t := tokens[0]
+	if t.word != "" {
+		return len(input) > 0 && input[0] == t.word && m.matchTokens(intent, tokens[1:], input[1:], slots)
+	}
+
+	for n := 1; n <= len(input); n++ {
+		value, ok := m.capture(m.slots[intent][t.slot], strings.Join(input[:n], " "))
+		if !ok {
+			continue
+		}
+
+		if m.matchTokens(intent, tokens[1:], input[n:], slots) {
+			slots[t.slot] = value
+			return true
+		}
+	}
+
+	return false
+}
+
+// capture returns the value of the slot type for the words or false if the type cannot capture them.
+func (m *Matcher) capture(slotType, value string) (string, bool) {
+	switch slotType {
+	case "AMAZON.NUMBER":
+		return parseNumber(m.lang, value)
+	default:
+		return value, true
+	}
+}
+
+// resolve returns the id and name of the custom type value matching the value or a synonym.
+func (m *Matcher) resolve(slotType, value string) (string, string, bool) {
+	t, ok := m.types[slotType]
+	if !ok {
+		return "", "", false
+	}
+
+	for _, v := range t.Values {
+		for _, name := range append([]string{v.Name.Value}, v.Name.Synonyms...) {
+			if strings.Join(words(name), " ") == value {
+				return v.ID, v.Name.Value, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// intent returns the intent of the match with resolutions of custom slot types.
+func (m *Matcher) intent(c match) alexa.Intent {
+	r := IntentRequest(c.intent)
+
+	for name, slotType := range m.slots[c.intent] {
+		value, ok := c.slots[name]
+
+		switch {
+		case !ok:
+			r.Request.Intent.Slots[name] = &alexa.Slot{Name: name}
+		case m.types[slotType].Name != "":
+			if id, resolved, ok := m.resolve(slotType, value); ok {
+				WithResolvedSlot(name, value, slotType, id, resolved)(r)
+			} else {
+				WithUnresolvedSlot(name, value, slotType)(r)
+			}
+		default:
+			WithSlot(name, value)(r)
+		}
+	}
+
+	return r.Request.Intent
+}
+
+// IntentRequest returns the request of the intent matching the utterance.
+func (m *Matcher) IntentRequest(utterance string, opts ...RequestFunc) (*alexa.RequestEnvelope, error) {
+	intent, err := m.Match(utterance)
+	if err != nil {
+		return nil, err
+	}
+
+	r := IntentRequest(intent.Name)
+	r.Request.Intent.Slots = intent.Slots
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,135 @@
+package alexatest_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	"github.com/drpsychick/go-alexa-lambda/skill"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func colorModel() *skill.Model {
+	return &skill.Model{Model: skill.InteractionModel{Language: skill.LanguageModel{
+		Invocation: "colors",
+		Intents: []skill.ModelIntent{
+			{Name: "ColorIntent", Samples: []string{"my favorite color is {color}", "{color}"}, Slots: []skill.ModelSlot{
+				{Name: "color", Type: "Color"},
+				{Name: "shade", Type: "Shade"},
+			}},
+			{Name: "CountIntent", Samples: []string{"count to {number}", "count"}, Slots: []skill.ModelSlot{
+				{Name: "number", Type: "AMAZON.NUMBER"},
+			}},
+			{Name: alexa.StopIntent},
+			{Name: alexa.FallbackIntent},
+		},
+		Types: []skill.ModelType{{Name: "Color", Values: []skill.TypeValue{
+			{ID: "RED", Name: skill.NameValue{Value: "red", Synonyms: []string{"crimson", "dark red"}}},
+			{ID: "BLUE", Name: skill.NameValue{Value: "blue"}},
+		}}},
+	}}}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	m := alexatest.NewMatcher(colorModel())
+
+	i, err := m.Match("My favorite color is Dark Red!")
+	assert.NoError(t, err)
+	assert.Equal(t, "ColorIntent", i.Name)
+	assert.Equal(t, "dark red", i.Slots["color"].Value)
+	assert.Equal(t, "", i.Slots["shade"].Value)
+
+	a, err := i.Slots["color"].FirstAuthorityWithMatch()
+	assert.NoError(t, err)
+	assert.Equal(t, "RED", a.Values[0].Value.ID)
+	assert.Equal(t, "red", a.Values[0].Value.Name)
+
+	i, err = m.Match("my favorite color is mauve")
+	assert.NoError(t, err)
+	_, err = i.Slots["color"].FirstAuthorityWithMatch()
+	assert.ErrorIs(t, err, alexa.ErrSlotNoResolutionWithMatch)
+
+	i, err = m.Match("count to 42")
+	assert.NoError(t, err)
+	assert.Equal(t, "CountIntent", i.Name)
+	assert.Equal(t, "42", i.Slots["number"].Value)
+
+	i, err = m.Match("Stop.")
+	assert.NoError(t, err)
+	assert.Equal(t, alexa.StopIntent, i.Name)
+
+	i, err = m.Match("count to many")
+	assert.NoError(t, err)
+	assert.Equal(t, alexa.FallbackIntent, i.Name)
+}
+
+func TestMatcher_Languages(t *testing.T) {
+	model := colorModel()
+	model.Model.Language.Intents = append(model.Model.Language.Intents, skill.ModelIntent{Name: "AMAZON.YesIntent"})
+
+	i, err := alexatest.NewMatcher(model).Match("ja")
+	assert.NoError(t, err)
+	assert.Equal(t, alexa.FallbackIntent, i.Name)
+
+	i, err = alexatest.NewMatcher(model, alexatest.WithMatcherLocale(alexa.LocaleGerman)).Match("Ja!")
+	assert.NoError(t, err)
+	assert.Equal(t, "AMAZON.YesIntent", i.Name)
+
+	i, err = alexatest.NewMatcher(model, alexatest.WithMatcherLocale(alexa.LocaleJapanese)).Match("はい")
+	assert.NoError(t, err)
+	assert.Equal(t, "AMAZON.YesIntent", i.Name)
+}
+
+func TestMatcher_SpelledOutNumbers(t *testing.T) {
+	tests := []struct {
+		locale    alexa.RequestLocale
+		utterance string
+		want      string
+	}{
+		{alexa.LocaleAmericanEnglish, "count to forty two", "42"},
+		{alexa.LocaleAmericanEnglish, "count to two hundred and five", "205"},
+		{alexa.LocaleAmericanEnglish, "count to three thousand", "3000"},
+		{alexa.LocaleGerman, "count to dreiundzwanzig", "23"},
+		{alexa.LocaleGerman, "count to zweihundertsiebzehn", "217"},
+		{alexa.LocaleFrench, "count to quatre-vingt-dix-sept", "97"},
+		{alexa.LocaleFrench, "count to vingt et un", "21"},
+		{alexa.LocaleItalian, "count to ventotto", "28"},
+		{alexa.LocaleItalian, "count to duemila", "2000"},
+		{alexa.LocaleSpanish, "count to treinta y uno", "31"},
+		{alexa.LocaleSpanish, "count to doscientos veintitrés", "223"},
+		{alexa.LocaleJapanese, "count to 二十三", "23"},
+		{alexa.LocaleJapanese, "count to 三千五百", "3500"},
+		{alexa.LocaleGerman, "count to 7", "7"},
+	}
+
+	for _, tt := range tests {
+		i, err := alexatest.NewMatcher(colorModel(), alexatest.WithMatcherLocale(tt.locale)).Match(tt.utterance)
+		assert.NoError(t, err)
+		assert.Equal(t, "CountIntent", i.Name, tt.utterance)
+		assert.Equal(t, tt.want, i.Slots["number"].Value, tt.utterance)
+	}
+
+	i, err := alexatest.NewMatcher(colorModel(), alexatest.WithMatcherLocale(alexa.LocaleGerman)).Match("count to forty")
+	assert.NoError(t, err)
+	assert.Equal(t, alexa.FallbackIntent, i.Name)
+}
+
+func TestMatcher_NoMatch(t *testing.T) {
+	model := colorModel()
+	model.Model.Language.Intents = model.Model.Language.Intents[1:2]
+
+	_, err := alexatest.NewMatcher(model).Match("hello")
+	assert.ErrorIs(t, err, alexatest.ErrNoMatch)
+}
+
+func TestSimulator_Say(t *testing.T) {
+	sim := alexatest.New(counterMux()).WithModel(colorModel())
+
+	sim.Run(t,
+		alexatest.Turn{Utterance: "crimson", Assert: []alexatest.Assertion{alexatest.SpeechContains("RED")}},
+		alexatest.Turn{Utterance: "blue", Assert: []alexatest.Assertion{alexatest.SpeechContains("BLUE")}},
+		alexatest.Turn{Utterance: "exit", Assert: []alexatest.Assertion{alexatest.SessionEnded()}},
+	)
+
+	_, err := alexatest.New(counterMux()).Say("stop")
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package alexatest
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numberKind is how a number word changes the number.
+type numberKind int
+
+const (
+	numberAdd numberKind = iota
+	numberHundred
+	numberThousand
+	numberConnector
+)
+
+type numberWord struct {
+	value int
+	kind  numberKind
+}
+
+// numberWords are the words of spelled-out numbers by language, compounds like "dreiundzwanzig" are split
+// into these words.
+var numberWords = map[string]map[string]numberWord{
+	"en": numbers(
+		[]string{
+			"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+			"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+		},
+		[]string{"twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"},
+		[]string{"hundred"}, []string{"thousand"}, []string{"and", "a"},
+		nil,
+	),
+	"de": numbers(
+		[]string{
+			"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun", "zehn",
+			"elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn",
+		},
+		[]string{"zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"},
+		[]string{"hundert"}, []string{"tausend"}, []string{"und"},
+		map[string]int{"ein": 1, "eine": 1, "einen": 1},
+	),
+	"fr": numbers(
+		[]string{
+			"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix",
+			"onze", "douze", "treize", "quatorze", "quinze", "seize",
+		},
+		[]string{"vingt", "trente", "quarante", "cinquante", "soixante"},
+		[]string{"cent", "cents"}, []string{"mille"}, []string{"et"},
+		map[string]int{"une": 1, "quatre-vingt": 80, "quatre-vingts": 80},
+	),
+	"it": numbers(
+		[]string{
+			"zero", "uno", "due", "tre", "quattro", "cinque", "sei", "sette", "otto", "nove", "dieci",
+			"undici", "dodici", "tredici", "quattordici", "quindici", "sedici", "diciassette", "diciotto", "diciannove",
+		},
+		[]string{"venti", "trenta", "quaranta", "cinquanta", "sessanta", "settanta", "ottanta", "novanta"},
+		[]string{"cento"}, []string{"mille", "mila"}, []string{"e"},
+		map[string]int{
+			"un": 1, "una": 1, "tré": 3, "vent": 20, "trent": 30, "quarant": 40, "cinquant": 50, "sessant": 60,
+			"settant": 70, "ottant": 80, "novant": 90,
+		},
+	),
+	"es": numbers(
+		[]string{
+			"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez",
+			"once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+			"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis",
+			"veintisiete", "veintiocho", "veintinueve",
+		},
+		[]string{"veinte", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"},
+		nil, []string{"mil"}, []string{"y"},
+		map[string]int{
+			"un": 1, "una": 1, "cien": 100, "ciento": 100, "doscientos": 200, "trescientos": 300,
+			"cuatrocientos": 400, "quinientos": 500, "seiscientos": 600, "setecientos": 700, "ochocientos": 800,
+			"novecientos": 900,
+		},
+	),
+}
+
+// numbers returns the number words of a language: units from zero, tens from twenty, words multiplying by
+// a hundred or thousand, connectors like "and" and other words adding their value.
+func numbers(units, tens, hundreds, thousands, connectors []string, other map[string]int) map[string]numberWord {
+	words := map[string]numberWord{}
+
+	for i, w := range units {
+		words[w] = numberWord{value: i}
+	}
+
+	for i, w := range tens {
+		words[w] = numberWord{value: (i + 2) * 10}
+	}
+
+	for _, w := range hundreds {
+		words[w] = numberWord{kind: numberHundred}
+	}
+
+	for _, w := range thousands {
+		words[w] = numberWord{kind: numberThousand}
+	}
+
+	for _, w := range connectors {
+		words[w] = numberWord{kind: numberConnector}
+	}
+
+	for w, v := range other {
+		words[w] = numberWord{value: v}
+	}
+
+	return words
+}
+
+// parseNumber returns the digits of a number spoken in the language, e.g. "23" for "twenty three",
+// "dreiundzwanzig" or "二十三", like Alexa fills AMAZON.NUMBER slots.
+func parseNumber(lang, value string) (string, bool) {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' }) < 0 {
+		return value, true
+	}
+
+	if lang == "ja" {
+		return parseKanjiNumber(value)
+	}
+
+	lexicon, ok := numberWords[lang]
+	if !ok {
+		return "", false
+	}
+
+	var tokens []numberWord
+
+	for _, w := range strings.Fields(value) {
+		// "quatre-vingt-dix" is split at hyphens, but "quatre-vingt" is a word
+		parts := []string{w}
+		if _, ok := lexicon[w]; !ok {
+			parts = strings.Split(strings.ReplaceAll(w, "quatre-vingt", "quatre_vingt"), "-")
+		}
+
+		for _, p := range parts {
+			split, ok := splitNumberWord(lexicon, strings.ReplaceAll(p, "_", "-"))
+			if !ok {
+				return "", false
+			}
+
+			tokens = append(tokens, split...)
+		}
+	}
+
+	var (
+		total, current int
+		found          bool
+	)
+
+	for _, t := range tokens {
+		switch t.kind {
+		case numberConnector:
+			continue
+		case numberHundred:
+			current = max(current, 1) * 100
+		case numberThousand:
+			total += max(current, 1) * 1000
+			current = 0
+		case numberAdd:
+			current += t.value
+		}
+
+		found = true
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return strconv.Itoa(total + current), true
+}
+
+// splitNumberWord splits a word into number words, e.g. "zweihundertdrei" into "zwei", "hundert" and "drei".
+func splitNumberWord(lexicon map[string]numberWord, word string) ([]numberWord, bool) {
+	if word == "" {
+		return nil, true
+	}
+
+	if w, ok := lexicon[word]; ok {
+		return []numberWord{w}, true
+	}
+
+	for i := len(word) - 1; i > 0; i-- {
+		w, ok := lexicon[word[:i]]
+		if !ok {
+			continue
+		}
+
+		if rest, ok := splitNumberWord(lexicon, word[i:]); ok {
+			return append([]numberWord{w}, rest...), true
+		}
+	}
+
+	return nil, false
+}
+
+// parseKanjiNumber returns the digits of a number in kanji, e.g. "23" for "二十三".
+func parseKanjiNumber(value string) (string, bool) {
+	digits := map[rune]int{
+		'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	}
+	scales := map[rune]int{'十': 10, '百': 100, '千': 1000}
+
+	var total, section, digit int
+
+	value = strings.Join(strings.Fields(value), "")
+	if value == "" {
+		return "", false
+	}
+
+	for _, r := range value {
+		if d, ok := digits[r]; ok {
+			digit = d
+			continue
+		}
+
+		if s, ok := scales[r]; ok {
+			section += max(digit, 1) * s
+			digit = 0
+
+			continue
+		}
+
+		if r != '万' {
+			return "", false
+		}
+
+		total += (section + digit) * 10000
+		section, digit = 0, 0
+	}
+
+	return strconv.Itoa(total + section + digit), true
+}
@@ -0,0 +1,44 @@
+package alexatest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// NewRemote returns a simulator of the skill served over HTTP at the url, e.g. by alexa.ListenAndServe.
+//
+// The options are applied to all requests, e.g. WithLocale.
+func NewRemote(url string, opts ...RequestFunc) *Simulator {
+	return &Simulator{invoke: remote(http.DefaultClient, url), opts: opts}
+}
+
+func remote(client *http.Client, url string) func(ctx context.Context, payload []byte) ([]byte, error) {
+	return func(ctx context.Context, payload []byte) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("alexatest: %s: %s", resp.Status, bytes.TrimSpace(body))
+		}
+
+		return body, nil
+	}
+}
@@ -0,0 +1,224 @@
+package alexatest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// maxLine is the maximum length of a recording.
+const maxLine = 10 << 20
+
+// Mismatch is a replayed request whose response differs from the recorded response.
+type Mismatch struct {
+	// Index is the position of the recording, starting at 1.
+	Index     int
+	RequestID string
+	Diff      []string
+}
+
+// Error returns the differences of the responses.
+func (m Mismatch) Error() string {
+	return fmt.Sprintf("recording %d (%s):\n\t%s", m.Index, m.RequestID, strings.Join(m.Diff, "\n\t"))
+}
+
+// ReadRecordings reads recordings as JSON lines, see alexa.Recorder.
+func ReadRecordings(r io.Reader) ([]alexa.Recording, error) {
+	var recs []alexa.Recording
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxLine)
+
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		rec := alexa.Recording{}
+		if err := jsoniter.UnmarshalFromString(line, &rec); err != nil {
+			return nil, fmt.Errorf("alexatest: line %d: %w", n, err)
+		}
+
+		if rec.Request == nil || rec.Request.Request == nil || rec.Response == nil {
+			return nil, fmt.Errorf("alexatest: line %d: not a recording", n)
+		}
+
+		recs = append(recs, rec)
+	}
+
+	return recs, sc.Err()
+}
+
+// ReplayConfig contains the options for Replay.
+type ReplayConfig struct {
+	Locales []l10n.LocaleInstance
+}
+
+// ReplayFunc defines the functions to be passed to Replay.
+type ReplayFunc func(cfg *ReplayConfig)
+
+// WithReplayVariations compares text rendered from any variation of the translations of the locales
+// as their first variation, so responses do not differ by the random pick of GetAny, see WithVariations.
+func WithReplayVariations(locales ...l10n.LocaleInstance) ReplayFunc {
+	return func(cfg *ReplayConfig) {
+		cfg.Locales = append(cfg.Locales, locales...)
+	}
+}
+
+// Replay sends the recorded requests as they were recorded, without the session and options of the simulator,
+// and returns the responses differing from the recorded responses.
+func (s *Simulator) Replay(recs []alexa.Recording, opts ...ReplayFunc) ([]Mismatch, error) {
+	cfg := ReplayConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n := newNormalizer(cfg.Locales)
+
+	var mismatches []Mismatch
+
+	for i, rec := range recs {
+		payload, err := jsoniter.Marshal(rec.Request)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := s.invoke(context.Background(), payload)
+		if err != nil {
+			return nil, fmt.Errorf("alexatest: recording %d: %w", i+1, err)
+		}
+
+		resp := &alexa.ResponseEnvelope{}
+		if err := jsoniter.Unmarshal(out, resp); err != nil {
+			return nil, fmt.Errorf("alexatest: recording %d: %w", i+1, err)
+		}
+
+		got, want := n.normalize(toJSONValue(resp)), n.normalize(toJSONValue(rec.Response))
+		if diff := diffValues(got, want); len(diff) > 0 {
+			mismatches = append(mismatches, Mismatch{Index: i + 1, RequestID: rec.Request.Request.RequestID, Diff: diff})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// ReplayFile replays the recordings of the file against the handler, failing the test for each mismatch.
+//
+// Recorded production traffic becomes a regression test:
+//
+//	func TestRecordings(t *testing.T) {
+//		alexatest.ReplayFile(t, newMux(), "testdata/recordings.jsonl", alexatest.WithReplayVariations(locales...))
+//	}
+func ReplayFile(t testing.TB, h alexa.Handler, file string, opts ...ReplayFunc) {
+	t.Helper()
+
+	f, err := os.Open(file) //nolint:gosec
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	recs, err := ReadRecordings(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mismatches, err := New(h).Replay(recs, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range mismatches {
+		t.Error(m)
+	}
+}
+
+// Diff returns the differences of the responses, one line per JSON value, e.g.
+// `response.outputSpeech.ssml: "<speak>Hi</speak>", want "<speak>Hello</speak>"`.
+func Diff(got, want *alexa.ResponseEnvelope) []string {
+	return diffValues(toJSONValue(got), toJSONValue(want))
+}
+
+// diffValues returns the differences of decoded JSON values.
+func diffValues(got, want interface{}) []string {
+	g, w := map[string]string{}, map[string]string{}
+	flatten("", got, g)
+	flatten("", want, w)
+
+	paths := make([]string, 0, len(g)+len(w))
+	for p := range g {
+		paths = append(paths, p)
+	}
+
+	for p := range w {
+		if _, ok := g[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+
+	sort.Strings(paths)
+
+	var diff []string
+
+	for _, p := range paths {
+		gv, gok := g[p]
+		wv, wok := w[p]
+
+		switch {
+		case !gok:
+			diff = append(diff, fmt.Sprintf("%s: missing, want %s", p, wv))
+		case !wok:
+			diff = append(diff, fmt.Sprintf("%s: %s, want none", p, gv))
+		case gv != wv:
+			diff = append(diff, fmt.Sprintf("%s: %s, want %s", p, gv, wv))
+		}
+	}
+
+	return diff
+}
+
+// toJSONValue returns the value as decoded from its JSON.
+func toJSONValue(v interface{}) interface{} {
+	var out interface{}
+
+	b, _ := jsoniter.Marshal(v)
+	_ = jsoniter.Unmarshal(b, &out)
+
+	return out
+}
+
+// flatten adds the JSON encoded values of v by their path.
+func flatten(path string, v interface{}, out map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			flatten(join(path, k), e, out)
+		}
+	case []interface{}:
+		for i, e := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), e, out)
+		}
+	default:
+		b, _ := jsoniter.Marshal(v)
+		out[path] = string(b)
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
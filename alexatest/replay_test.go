@@ -0,0 +1,101 @@
+package alexatest_test
+
+import (
+	"bytes"
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func record(t *testing.T, h alexa.Handler, reqs ...*alexa.RequestEnvelope) []byte {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	sim := alexatest.New(alexa.NewRecorder(h, out))
+
+	for _, r := range reqs {
+		_, err := sim.Send(r)
+		require.NoError(t, err)
+	}
+
+	return out.Bytes()
+}
+
+func TestReplay(t *testing.T) {
+	recorded := record(t, counterMux(),
+		alexatest.LaunchRequest(alexatest.WithUserID("amzn1.ask.account.SECRET")),
+		alexatest.IntentRequest("CountIntent"),
+		alexatest.IntentRequest("CountIntent", alexatest.WithSlot("by", "ten")),
+	)
+	assert.NotContains(t, string(recorded), "SECRET")
+
+	recs, err := alexatest.ReadRecordings(bytes.NewReader(recorded))
+	require.NoError(t, err)
+	require.Len(t, recs, 3)
+
+	mismatches, err := alexatest.New(counterMux()).Replay(recs)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	changed := counterMux()
+	changed.HandleIntentFunc("CountIntent", func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+		b.WithSpeech("Counting").WithSessionAttributes(r.Session.Attributes)
+	})
+
+	mismatches, err = alexatest.New(changed).Replay(recs)
+	require.NoError(t, err)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, 2, mismatches[0].Index)
+	assert.Equal(t, recs[1].Request.Request.RequestID, mismatches[0].RequestID)
+	assert.Equal(t, []string{
+		`response.card.content: missing, want "en-US"`,
+		`response.card.title: missing, want "Count"`,
+		`response.card.type: missing, want "Simple"`,
+		`response.outputSpeech.text: "Counting", want "Counted"`,
+		`sessionAttributes.count: 0, want 1`,
+	}, mismatches[0].Diff)
+	assert.Contains(t, mismatches[1].Error(), "recording 3 (")
+}
+
+func TestReplay_WithReplayVariations(t *testing.T) {
+	loc := l10n.NewLocale("en-US")
+	loc.Set("Greeting", []string{"Hello {name}!", "Hi {name}, welcome!"})
+
+	greet := func(i int) alexa.Handler {
+		return alexa.HandlerFunc(func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+			b.WithSpeech(loc.GetAll("Greeting", l10n.Args{"name": "Bob"})[i])
+		})
+	}
+
+	recs, err := alexatest.ReadRecordings(bytes.NewReader(record(t, greet(1), alexatest.LaunchRequest())))
+	require.NoError(t, err)
+
+	mismatches, err := alexatest.New(greet(0)).Replay(recs)
+	require.NoError(t, err)
+	assert.Len(t, mismatches, 1)
+
+	mismatches, err = alexatest.New(greet(0)).Replay(recs, alexatest.WithReplayVariations(loc))
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
+
+func TestReadRecordings_Errors(t *testing.T) {
+	_, err := alexatest.ReadRecordings(strings.NewReader("\n{\"request\": 1}\n"))
+	assert.ErrorContains(t, err, "alexatest: line 2: ")
+
+	_, err = alexatest.ReadRecordings(strings.NewReader(`{"request": {}}`))
+	assert.EqualError(t, err, "alexatest: line 1: not a recording")
+}
+
+func TestReplayFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "recordings.jsonl")
+	require.NoError(t, os.WriteFile(file, record(t, counterMux(), alexatest.LaunchRequest()), 0o600))
+
+	alexatest.ReplayFile(t, counterMux(), file)
+}
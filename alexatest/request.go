@@ -0,0 +1,182 @@
+// Package alexatest provides utilities to test Alexa skills end to end.
+package alexatest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/drpsychick/go-alexa-lambda"
+)
+
+// Defaults of generated requests.
+const (
+	DefaultLocale        = alexa.LocaleAmericanEnglish
+	DefaultApplicationID = "amzn1.ask.skill.00000000-0000-0000-0000-000000000000"
+	DefaultUserID        = "amzn1.ask.account.TEST"
+	DefaultDeviceID      = "amzn1.ask.device.TEST"
+)
+
+var ids uint64
+
+// id returns a unique id with the prefix, e.g. "amzn1.echo-api.request.1".
+func id(prefix string) string {
+	return fmt.Sprintf("%s.%d", prefix, atomic.AddUint64(&ids, 1))
+}
+
+// RequestFunc defines the functions to be passed to the request constructors.
+type RequestFunc func(r *alexa.RequestEnvelope)
+
+// NewRequest returns a request of the type like Alexa sends it, with a new session, context and user.
+func NewRequest(typ alexa.RequestType, opts ...RequestFunc) *alexa.RequestEnvelope {
+	app := &alexa.ContextApplication{ApplicationID: DefaultApplicationID}
+	user := &alexa.ContextUser{UserID: DefaultUserID}
+
+	system := &alexa.ContextSystem{
+		APIEndpoint: "https://api.amazonalexa.com",
+		User:        user,
+		Application: app,
+	}
+	system.Device.DeviceID = DefaultDeviceID
+	system.Device.SupportedInterfaces = map[string]struct{}{}
+
+	r := &alexa.RequestEnvelope{
+		Version: "1.0",
+		Session: &alexa.Session{
+			New:         true,
+			SessionID:   id("amzn1.echo-api.session"),
+			Application: app,
+			Attributes:  map[string]interface{}{},
+			User:        user,
+		},
+		Context: &alexa.Context{System: system},
+		Request: &alexa.Request{
+			Type:      typ,
+			RequestID: id("amzn1.echo-api.request"),
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Locale:    DefaultLocale,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// LaunchRequest returns a launch request.
+func LaunchRequest(opts ...RequestFunc) *alexa.RequestEnvelope {
+	return NewRequest(alexa.TypeLaunchRequest, opts...)
+}
+
+// IntentRequest returns a request of the intent.
+func IntentRequest(intent string, opts ...RequestFunc) *alexa.RequestEnvelope {
+	r := NewRequest(alexa.TypeIntentRequest)
+	r.Request.Intent = alexa.Intent{
+		Name:               intent,
+		Slots:              map[string]*alexa.Slot{},
+		ConfirmationStatus: alexa.ConfirmationStatusNone,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// SessionEndedRequest returns a request ending the session for the reason, e.g. "USER_INITIATED".
+func SessionEndedRequest(reason string, opts ...RequestFunc) *alexa.RequestEnvelope {
+	r := NewRequest(alexa.TypeSessionEndedRequest, opts...)
+	r.Request.Reason = reason
+
+	return r
+}
+
+// WithLocale sets the locale of the request.
+func WithLocale(locale alexa.RequestLocale) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.Locale = locale
+	}
+}
+
+// WithSlot adds a slot with the value as spoken by the user.
+func WithSlot(name, value string) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.Intent.Slots[name] = &alexa.Slot{Name: name, Value: value, Source: "USER"}
+	}
+}
+
+// WithResolvedSlot adds a slot with the value resolved to the custom slot type value of the id and name.
+func WithResolvedSlot(name, value, slotType, valueID, valueName string) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.Intent.Slots[name] = &alexa.Slot{
+			Name:   name,
+			Value:  value,
+			Source: "USER",
+			Resolutions: &alexa.Resolutions{ResolutionsPerAuthority: []*alexa.PerAuthority{{
+				Authority: Authority(slotType),
+				Status:    &alexa.ResolutionStatus{Code: alexa.ResolutionStatusMatch},
+				Values:    []*alexa.AuthorityValue{{Value: &alexa.AuthorityValueValue{Name: valueName, ID: valueID}}},
+			}}},
+		}
+	}
+}
+
+// WithUnresolvedSlot adds a slot with a value not matching any value of the custom slot type.
+func WithUnresolvedSlot(name, value, slotType string) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.Intent.Slots[name] = &alexa.Slot{
+			Name:   name,
+			Value:  value,
+			Source: "USER",
+			Resolutions: &alexa.Resolutions{ResolutionsPerAuthority: []*alexa.PerAuthority{{
+				Authority: Authority(slotType),
+				Status:    &alexa.ResolutionStatus{Code: alexa.ResolutionStatusNoMatch},
+			}}},
+		}
+	}
+}
+
+// Authority returns the entity resolution authority of the custom slot type.
+func Authority(slotType string) string {
+	return "amzn1.er-authority.echo-sdk." + DefaultApplicationID + "." + slotType
+}
+
+// WithDialogState sets the dialog state of the request.
+func WithDialogState(state alexa.DialogStateType) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.DialogState = state
+	}
+}
+
+// WithConfirmation sets the confirmation status of the intent.
+func WithConfirmation(status alexa.ConfirmationStatus) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Request.Intent.ConfirmationStatus = status
+	}
+}
+
+// WithSessionAttributes sets the session attributes of the request.
+func WithSessionAttributes(attrs map[string]interface{}) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Session.Attributes = attrs
+	}
+}
+
+// WithUserID sets the id of the user in the session and context.
+func WithUserID(userID string) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		r.Session.User.UserID = userID
+	}
+}
+
+// WithSupportedInterfaces sets the interfaces supported by the device, e.g. "AudioPlayer".
+func WithSupportedInterfaces(names ...string) RequestFunc {
+	return func(r *alexa.RequestEnvelope) {
+		for _, n := range names {
+			r.Context.System.Device.SupportedInterfaces[n] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package alexatest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/skill"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ErrSessionEnded is returned when sending a request to a session ended by the skill.
+var ErrSessionEnded = errors.New("alexatest: session ended")
+
+// Simulator drives a handler through a conversation like Alexa, carrying the session between requests.
+//
+// Requests and responses are serialized to JSON and served by an alexa.Server, like on Lambda,
+// or posted to a skill served over HTTP, see NewRemote.
+type Simulator struct {
+	invoke  func(ctx context.Context, payload []byte) ([]byte, error)
+	opts    []RequestFunc
+	matcher *Matcher
+
+	session *alexa.Session
+	ended   bool
+}
+
+// New returns a simulator of the handler, the options are applied to all requests, e.g. WithLocale.
+func New(h alexa.Handler, opts ...RequestFunc) *Simulator {
+	srv := &alexa.Server{Handler: h}
+
+	return &Simulator{invoke: srv.Invoke, opts: opts}
+}
+
+// WithModel matches utterances against the interaction model in the locale of the requests, see Say.
+func (s *Simulator) WithModel(model *skill.Model) *Simulator {
+	locale := NewRequest(alexa.TypeIntentRequest, s.opts...).Request.Locale
+	s.matcher = NewMatcher(model, WithMatcherLocale(locale))
+
+	return s
+}
+
+// Say sends the intent request matching the utterance, see Matcher.
+func (s *Simulator) Say(utterance string, opts ...RequestFunc) (*alexa.ResponseEnvelope, error) {
+	if s.matcher == nil {
+		return nil, errors.New("alexatest: no model to match utterances, see WithModel")
+	}
+
+	req, err := s.matcher.IntentRequest(utterance, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Send(req)
+}
+
+// Send sends the request in the current session and returns the response.
+//
+// The first request starts a new session, following requests continue it with the session attributes of the
+// previous response, merged with the attributes of the request. A SessionEndedRequest or a response ending
+// the session ends the session, following requests fail with ErrSessionEnded until Reset.
+// The request is not changed, the options and the session are applied to a copy.
+func (s *Simulator) Send(req *alexa.RequestEnvelope) (*alexa.ResponseEnvelope, error) {
+	if s.ended {
+		return nil, ErrSessionEnded
+	}
+
+	r := &alexa.RequestEnvelope{}
+	if err := copyJSON(req, r); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range s.opts {
+		opt(r)
+	}
+
+	r.Session = s.continueSession(r.Session)
+
+	payload, err := jsoniter.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.invoke(context.Background(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &alexa.ResponseEnvelope{}
+	if err := jsoniter.Unmarshal(out, resp); err != nil {
+		return nil, err
+	}
+
+	// session attributes are only kept if returned by the skill
+	s.session = r.Session
+	s.session.Attributes = resp.SessionAttributes
+	s.ended = resp.Response.ShouldEndSession || r.RequestType() == alexa.TypeSessionEndedRequest
+
+	return resp, nil
+}
+
+// continueSession returns a new session continuing the current session with the attributes and user
+// of the session of the request, or the session of the request if there is no current session.
+func (s *Simulator) continueSession(sess *alexa.Session) *alexa.Session {
+	if s.session == nil {
+		if sess == nil {
+			sess = &alexa.Session{New: true, SessionID: id("amzn1.echo-api.session")}
+		}
+
+		return sess
+	}
+
+	next := *s.session
+	next.New = false
+	next.Attributes = make(map[string]interface{}, len(s.session.Attributes))
+
+	for k, v := range s.session.Attributes {
+		next.Attributes[k] = v
+	}
+
+	if sess != nil {
+		for k, v := range sess.Attributes {
+			next.Attributes[k] = v
+		}
+
+		if sess.User != nil {
+			next.User = sess.User
+		}
+	}
+
+	return &next
+}
+
+// copyJSON copies src into dst through JSON, like the request is sent to the skill.
+func copyJSON(src, dst interface{}) error {
+	b, err := jsoniter.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return jsoniter.Unmarshal(b, dst)
+}
+
+// Reset ends the session, the next request starts a new session.
+func (s *Simulator) Reset() {
+	s.session = nil
+	s.ended = false
+}
+
+// Session returns a copy of the current session or nil if no session is started.
+func (s *Simulator) Session() *alexa.Session {
+	if s.session == nil {
+		return nil
+	}
+
+	sess := *s.session
+
+	return &sess
+}
+
+// SessionAttributes returns the session attributes of the last response.
+func (s *Simulator) SessionAttributes() map[string]interface{} {
+	if s.session == nil {
+		return nil
+	}
+
+	return s.session.Attributes
+}
+
+// Ended returns true if the session has ended.
+func (s *Simulator) Ended() bool {
+	return s.ended
+}
+
+// Turn is a request or utterance of a conversation with the assertions on its response.
+type Turn struct {
+	Request *alexa.RequestEnvelope
+	// Utterance is said instead of sending a request, see Say.
+	Utterance string
+	Assert    []Assertion
+}
+
+// Run sends the requests of the turns in order and asserts on the responses.
+func (s *Simulator) Run(t testing.TB, turns ...Turn) {
+	t.Helper()
+
+	for i, turn := range turns {
+		var (
+			resp *alexa.ResponseEnvelope
+			err  error
+		)
+
+		if turn.Request == nil {
+			resp, err = s.Say(turn.Utterance)
+		} else {
+			resp, err = s.Send(turn.Request)
+		}
+
+		if err != nil {
+			t.Fatalf("turn %d: %v", i+1, err)
+		}
+
+		for _, a := range turn.Assert {
+			if err := a(resp); err != nil {
+				t.Errorf("turn %d: %v", i+1, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package alexatest_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	log "github.com/hamba/logger/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func counterMux() *alexa.ServeMux {
+	mux := alexa.NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.HandleRequestTypeFunc(alexa.TypeLaunchRequest, func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+		b.WithSpeech("Welcome").WithSessionAttributes(map[string]interface{}{"count": 0})
+	})
+	mux.HandleIntentFunc("CountIntent", func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+		count, _ := r.Session.Attributes["count"].(float64)
+		if r.SlotValue("by") == "ten" {
+			count += 10
+		} else {
+			count++
+		}
+
+		b.WithSpeech("Counted").WithSimpleCard("Count", r.RequestLocale()).
+			WithSessionAttributes(map[string]interface{}{"count": count})
+	})
+	mux.HandleIntentFunc("ColorIntent", func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+		s, _ := r.Slot("color")
+		a, err := s.FirstAuthorityWithMatch()
+		if err != nil {
+			b.AddDirective(&alexa.Directive{Type: alexa.DirectiveTypeDialogElicitSlot, SlotToElicit: "color"})
+			return
+		}
+
+		b.WithSpeech(a.Values[0].Value.ID)
+	})
+	mux.HandleIntentFunc(alexa.StopIntent, func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+		b.WithSpeech("<speak>Bye</speak>").WithShouldEndSession(true)
+	})
+
+	return mux
+}
+
+func TestSimulator_Run(t *testing.T) {
+	sim := alexatest.New(counterMux(), alexatest.WithLocale(alexa.LocaleGerman))
+
+	sim.Run(t,
+		alexatest.Turn{
+			Request: alexatest.LaunchRequest(),
+			Assert:  []alexatest.Assertion{alexatest.SpeechContains("Welcome"), alexatest.SessionOpen()},
+		},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest("CountIntent", alexatest.WithSlot("by", "ten")),
+			Assert: []alexatest.Assertion{
+				alexatest.CardTitle("Count"),
+				alexatest.CardContains("de-DE"),
+				alexatest.SessionAttribute("count", 10.0),
+			},
+		},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest("CountIntent"),
+			Assert:  []alexatest.Assertion{alexatest.SessionAttribute("count", 11.0)},
+		},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest("ColorIntent", alexatest.WithUnresolvedSlot("color", "mauve", "Color")),
+			Assert:  []alexatest.Assertion{alexatest.ElicitsSlot("color")},
+		},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest("ColorIntent",
+				alexatest.WithResolvedSlot("color", "crimson", "Color", "RED", "red")),
+			Assert: []alexatest.Assertion{alexatest.SpeechContains("RED")},
+		},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest(alexa.StopIntent),
+			Assert:  []alexatest.Assertion{alexatest.SpeechContains("Bye"), alexatest.SessionEnded()},
+		},
+	)
+
+	assert.True(t, sim.Ended())
+}
+
+func TestSimulator_Send(t *testing.T) {
+	sim := alexatest.New(counterMux())
+
+	first := alexatest.LaunchRequest()
+	_, err := sim.Send(first)
+	assert.NoError(t, err)
+
+	next := alexatest.IntentRequest("CountIntent")
+	resp, err := sim.Send(next)
+	assert.NoError(t, err)
+	assert.False(t, sim.Session().New)
+	assert.Equal(t, first.Session.SessionID, sim.Session().SessionID)
+	assert.Equal(t, 1.0, resp.SessionAttributes["count"])
+
+	// the request is not changed
+	assert.True(t, next.Session.New)
+	assert.NotEqual(t, first.Session.SessionID, next.Session.SessionID)
+
+	_, err = sim.Send(alexatest.SessionEndedRequest("USER_INITIATED"))
+	assert.NoError(t, err)
+	assert.True(t, sim.Ended())
+
+	_, err = sim.Send(alexatest.LaunchRequest())
+	assert.ErrorIs(t, err, alexatest.ErrSessionEnded)
+
+	sim.Reset()
+	resp, err = sim.Send(alexatest.IntentRequest("CountIntent"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, resp.SessionAttributes["count"])
+}
+
+func TestSimulator_SendSession(t *testing.T) {
+	sim := alexatest.New(counterMux())
+
+	// requests without session start a new session
+	req := alexatest.LaunchRequest()
+	req.Session = nil
+	_, err := sim.Send(req)
+	assert.NoError(t, err)
+	assert.Nil(t, req.Session)
+	assert.NotEmpty(t, sim.Session().SessionID)
+
+	resp, err := sim.Send(alexatest.IntentRequest("CountIntent"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, resp.SessionAttributes["count"])
+
+	// attributes and user of the request are merged into the session
+	next := alexatest.IntentRequest("CountIntent",
+		alexatest.WithSessionAttributes(map[string]interface{}{"extra": "yes"}), alexatest.WithUserID("bob"))
+	resp, err = sim.Send(next)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, resp.SessionAttributes["count"])
+	assert.Equal(t, "bob", sim.Session().User.UserID)
+	assert.Equal(t, map[string]interface{}{"extra": "yes"}, next.Session.Attributes)
+
+	// requests without session continue the session
+	req = alexatest.IntentRequest("CountIntent")
+	req.Session = nil
+	resp, err = sim.Send(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, resp.SessionAttributes["count"])
+	assert.Nil(t, req.Session)
+}
+
+func TestNewRemote(t *testing.T) {
+	srv := httptest.NewServer(counterMux())
+	defer srv.Close()
+
+	sim := alexatest.NewRemote(srv.URL)
+	sim.Run(t,
+		alexatest.Turn{Request: alexatest.LaunchRequest(), Assert: []alexatest.Assertion{alexatest.SpeechContains("Welcome")}},
+		alexatest.Turn{
+			Request: alexatest.IntentRequest("CountIntent"),
+			Assert:  []alexatest.Assertion{alexatest.SessionAttribute("count", 1.0)},
+		},
+	)
+
+	_, err := alexatest.NewRemote("http://127.0.0.1:0").Send(alexatest.LaunchRequest())
+	assert.Error(t, err)
+}
+
+func TestAssertions(t *testing.T) {
+	resp := &alexa.ResponseEnvelope{}
+
+	assert.Error(t, alexatest.SpeechContains("Hi")(resp))
+	assert.Error(t, alexatest.RepromptContains("Hi")(resp))
+	assert.Error(t, alexatest.CardTitle("Hi")(resp))
+	assert.Error(t, alexatest.CardContains("Hi")(resp))
+	assert.Error(t, alexatest.HasDirective(alexa.DirectiveTypeDialogDelegate)(resp))
+	assert.Error(t, alexatest.SessionEnded()(resp))
+	assert.Error(t, alexatest.SessionAttribute("foo", "bar")(resp))
+	assert.NoError(t, alexatest.SessionOpen()(resp))
+}
@@ -0,0 +1,196 @@
+package alexatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	// DefaultSnapshotDir is the directory of the golden files of AssertSnapshot.
+	DefaultSnapshotDir = "testdata/snapshots"
+	// UpdateFlag is the test flag for AssertSnapshot to write the golden files, e.g. -alexatest.update.
+	UpdateFlag = "alexatest.update"
+	// UpdateEnv is the environment variable to write the golden files if the flag is not given,
+	// e.g. ALEXATEST_UPDATE=1.
+	UpdateEnv = "ALEXATEST_UPDATE"
+)
+
+var update = flag.Bool(UpdateFlag, false, "write the golden files of alexatest.AssertSnapshot")
+
+// SnapshotConfig contains the options for AssertSnapshot.
+type SnapshotConfig struct {
+	Dir     string
+	Name    string
+	Locales []l10n.LocaleInstance
+}
+
+// SnapshotFunc defines the functions to be passed to AssertSnapshot.
+type SnapshotFunc func(cfg *SnapshotConfig)
+
+// WithSnapshotDir sets the directory of the golden files, DefaultSnapshotDir by default.
+func WithSnapshotDir(dir string) SnapshotFunc {
+	return func(cfg *SnapshotConfig) {
+		cfg.Dir = dir
+	}
+}
+
+// WithSnapshotName sets the name of the golden file, the name of the test by default.
+func WithSnapshotName(name string) SnapshotFunc {
+	return func(cfg *SnapshotConfig) {
+		cfg.Name = name
+	}
+}
+
+// WithVariations normalizes the random variations of the translations of the locales to their first variation,
+// see Snapshot.
+func WithVariations(locales ...l10n.LocaleInstance) SnapshotFunc {
+	return func(cfg *SnapshotConfig) {
+		cfg.Locales = append(cfg.Locales, locales...)
+	}
+}
+
+// Snapshot returns the canonical JSON of the response, with sorted keys and indented.
+//
+// With WithVariations, text rendered from any variation of a translation (see l10n.LocaleInstance.GetAny)
+// is replaced by the first variation with the same arguments, so snapshots don't change with the random pick.
+func Snapshot(resp *alexa.ResponseEnvelope, opts ...SnapshotFunc) ([]byte, error) {
+	cfg := SnapshotConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return snapshot(toJSONValue(resp), newNormalizer(cfg.Locales))
+}
+
+func snapshot(v interface{}, n *normalizer) ([]byte, error) {
+	// maps are encoded with sorted keys, SSML tags are not escaped
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(n.normalize(v)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var (
+	snapshotsMu sync.Mutex
+	snapshots   = map[testing.TB]int{}
+)
+
+// AssertSnapshot compares the canonical JSON of the response with its golden file, see Snapshot.
+//
+// The golden file is named after the test, e.g. "testdata/snapshots/TestLaunch.json", following snapshots of
+// the same test are numbered, e.g. "TestLaunch_2.json". Run the tests with the UpdateFlag (or UpdateEnv set)
+// to write the golden files and review their changes like code:
+//
+//	go test ./skill -run TestLaunch -alexatest.update
+//	ALEXATEST_UPDATE=1 go test ./...
+//
+// The flag is only known to test binaries of packages importing alexatest, use the environment variable
+// to update the snapshots of several packages at once.
+func AssertSnapshot(t testing.TB, resp *alexa.ResponseEnvelope, opts ...SnapshotFunc) {
+	t.Helper()
+
+	cfg := SnapshotConfig{Dir: DefaultSnapshotDir}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	file := filepath.Join(cfg.Dir, snapshotName(t, cfg.Name)+".json")
+
+	got := newNormalizer(cfg.Locales).normalize(toJSONValue(resp))
+
+	b, err := snapshot(got, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updating() {
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(file, b, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	golden, err := os.ReadFile(file) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("no snapshot %s, run the test with -%s or %s=1 to write it", file, UpdateFlag, UpdateEnv)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want interface{}
+	if err := jsoniter.Unmarshal(golden, &want); err != nil {
+		t.Fatalf("snapshot %s: %v", file, err)
+	}
+
+	if diff := diffValues(got, want); len(diff) > 0 {
+		t.Errorf("response differs from snapshot %s, run the test with %s=1 to update it:\n\t%s",
+			file, UpdateEnv, strings.Join(diff, "\n\t"))
+	}
+}
+
+// updating returns true if the golden files are written, see UpdateEnv.
+// updating returns true if the golden files are written, see UpdateFlag and UpdateEnv.
+func updating() bool {
+	if *update {
+		return true
+	}
+
+	env, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+
+	return env
+}
+
+// snapshotName returns the name of the next snapshot of the test.
+func snapshotName(t testing.TB, name string) string {
+	snapshotsMu.Lock()
+	defer snapshotsMu.Unlock()
+
+	if name != "" {
+		return name
+	}
+
+	snapshots[t]++
+	n := snapshots[t]
+
+	if n == 1 {
+		t.Cleanup(func() {
+			snapshotsMu.Lock()
+			defer snapshotsMu.Unlock()
+
+			delete(snapshots, t)
+		})
+	}
+
+	name = strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	if n > 1 {
+		name += "_" + strconv.Itoa(n)
+	}
+
+	return name
+}
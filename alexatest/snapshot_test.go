@@ -0,0 +1,131 @@
+package alexatest_test
+
+import (
+	"flag"
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	b := &alexa.ResponseBuilder{}
+	b.WithSpeech("<speak>Tom &amp; Jerry</speak>").
+		WithSessionAttributes(map[string]interface{}{"zebra": 1, "apple": map[string]interface{}{"b": true, "a": "x"}})
+
+	got, err := alexatest.Snapshot(b.Build())
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "response": {
+    "outputSpeech": {
+      "ssml": "<speak>Tom &amp; Jerry</speak>",
+      "type": "SSML"
+    },
+    "shouldEndSession": false
+  },
+  "sessionAttributes": {
+    "apple": {
+      "a": "x",
+      "b": true
+    },
+    "zebra": 1
+  },
+  "version": "1.0"
+}
+`, string(got))
+}
+
+func TestSnapshot_WithVariations(t *testing.T) {
+	loc := l10n.NewLocale("en-US")
+	loc.Set("Greeting", []string{"Hello {name}!", "Hi {name}, welcome!", "Hey {name}"})
+	loc.Set("Score", []string{"You have %d points.", "Your score is %d", "%d points for %s"})
+	loc.Set("Bye", []string{"Bye", "Goodbye", "See you"})
+	loc.Set("Single", []string{"Only one"})
+
+	tests := []struct {
+		name   string
+		speech string
+		want   string
+	}{
+		{"first", "<speak>Hello Bob!</speak>", "<speak>Hello Bob!</speak>"},
+		{"named", "<speak>Hi Bob Marley, welcome!</speak>", "<speak>Hello Bob Marley!</speak>"},
+		{"trailing", "<speak>Hey Bob. Goodbye</speak>", "<speak>Hello Bob!. Bye</speak>"},
+		{"contained", "<speak>Goodbye</speak>", "<speak>Bye</speak>"},
+		{"verbs", "<speak>Your score is 42</speak>", "<speak>You have 42 points.</speak>"},
+		{"unused verb", "<speak>7 points for Bob</speak>", "<speak>You have 7 points.</speak>"},
+		{"unknown", "<speak>See you later</speak>", "<speak>Bye later</speak>"},
+
+		{"single", "<speak>Only one</speak>", "<speak>Only one</speak>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &alexa.ResponseBuilder{}
+			b.WithSpeech(tt.speech)
+
+			got, err := alexatest.Snapshot(b.Build(), alexatest.WithVariations(loc))
+			require.NoError(t, err)
+
+			want, err := alexatest.Snapshot((&alexa.ResponseBuilder{}).WithSpeech(tt.want).Build())
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestSnapshot_WithVariationsWords(t *testing.T) {
+	loc := l10n.NewLocale("en-US")
+	loc.Set("Greeting", []string{"Hello", "Hi"})
+	loc.Set("Sure", []string{"Sure.", "Really? (yes)"})
+
+	b := &alexa.ResponseBuilder{}
+	b.WithSpeech("<speak>This is History. Hi there. Really? (yes)</speak>")
+
+	got, err := alexatest.Snapshot(b.Build(), alexatest.WithVariations(loc))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"<speak>This is History. Hello there. Sure.</speak>"`)
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	sim := alexatest.New(counterMux())
+
+	resp, err := sim.Send(alexatest.LaunchRequest())
+	require.NoError(t, err)
+	alexatest.AssertSnapshot(t, resp)
+
+	resp, err = sim.Send(alexatest.IntentRequest("CountIntent"))
+	require.NoError(t, err)
+	alexatest.AssertSnapshot(t, resp)
+}
+
+func TestAssertSnapshot_Update(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(alexatest.UpdateEnv, "true")
+	alexatest.AssertSnapshot(t, (&alexa.ResponseBuilder{}).WithSpeech("Hello").Build(), alexatest.WithSnapshotDir(dir))
+	alexatest.AssertSnapshot(t, (&alexa.ResponseBuilder{}).WithSpeech("Bye").Build(),
+		alexatest.WithSnapshotDir(dir), alexatest.WithSnapshotName("bye"))
+	t.Setenv(alexatest.UpdateEnv, "")
+
+	b, err := os.ReadFile(filepath.Join(dir, "TestAssertSnapshot_Update.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"text": "Hello"`)
+	assert.FileExists(t, filepath.Join(dir, "bye.json"))
+
+	alexatest.AssertSnapshot(t, (&alexa.ResponseBuilder{}).WithSpeech("Hello").Build(),
+		alexatest.WithSnapshotDir(dir), alexatest.WithSnapshotName("TestAssertSnapshot_Update"))
+}
+
+func TestAssertSnapshot_UpdateFlag(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, flag.Set(alexatest.UpdateFlag, "true"))
+	alexatest.AssertSnapshot(t, (&alexa.ResponseBuilder{}).WithSpeech("Hello").Build(), alexatest.WithSnapshotDir(dir))
+	require.NoError(t, flag.Set(alexatest.UpdateFlag, "false"))
+
+	assert.FileExists(t, filepath.Join(dir, "TestAssertSnapshot_UpdateFlag.json"))
+}
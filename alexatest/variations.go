@@ -0,0 +1,280 @@
+package alexatest
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+)
+
+// verbPattern matches fmt verbs and escaped percent signs.
+var verbPattern = regexp.MustCompile(
+	`%(?:%|[-+# 0]*(?:\[\d+])?(?:\d+|\*)?(?:\.(?:\[\d+])?(?:\d+|\*)?)?(?:\[\d+])?[a-zA-Z])`,
+)
+
+// piece is a literal or a placeholder of a translation.
+type piece struct {
+	literal string
+	// arg is the name or position of the placeholder.
+	arg string
+	raw string
+}
+
+// variations are the variations of a translation, matched by one pattern.
+type variations struct {
+	pattern *regexp.Regexp
+	// first are the pieces of the first variation.
+	first []piece
+	// groups are the placeholders of each alternative of the pattern, the alternative itself is captured first.
+	groups [][]string
+}
+
+// normalizer replaces text rendered from variations of translations by their first variation.
+type normalizer struct {
+	keys []*variations
+}
+
+func newNormalizer(locales []l10n.LocaleInstance) *normalizer {
+	n := &normalizer{}
+
+	for _, loc := range locales {
+		s, ok := loc.(interface{ GetSnippets() l10n.Snippets })
+		if !ok {
+			continue
+		}
+
+		snippets := s.GetSnippets()
+
+		keys := make([]string, 0, len(snippets))
+		for k := range snippets {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if v := newVariations(snippets[k]); v != nil {
+				n.keys = append(n.keys, v)
+			}
+		}
+	}
+
+	return n
+}
+
+// newVariations returns the variations of the translations, or nil if there is nothing to normalize.
+func newVariations(values []string) *variations {
+	if len(values) < 2 {
+		return nil
+	}
+
+	all := make([][]piece, 0, len(values))
+
+	for _, value := range values {
+		p, ok := pieces(value)
+		if !ok || literalLen(p) == 0 {
+			return nil
+		}
+
+		all = append(all, p)
+	}
+
+	v := &variations{first: all[0]}
+
+	// longer variations first, so variations contained in others do not match their part
+	sort.SliceStable(all, func(i, j int) bool { return literalLen(all[i]) > literalLen(all[j]) })
+
+	alternatives := make([]string, 0, len(all))
+
+	for _, variation := range all {
+		var (
+			sb     strings.Builder
+			groups []string
+		)
+
+		for i, p := range variation {
+			switch {
+			case p.arg == "":
+				sb.WriteString(literalPattern(p.literal, i == 0, i == len(variation)-1))
+			case i == len(variation)-2 && variation[i+1].literal == "":
+				// a trailing placeholder captures up to the end of a word
+				sb.WriteString(`([^<>]+?)\b`)
+			default:
+				sb.WriteString(`([^<>]+?)`)
+			}
+
+			if p.arg != "" {
+				groups = append(groups, p.arg)
+			}
+		}
+
+		alternatives = append(alternatives, "("+sb.String()+")")
+		v.groups = append(v.groups, groups)
+	}
+
+	v.pattern = regexp.MustCompile(strings.Join(alternatives, "|"))
+
+	return v
+}
+
+// literalPattern returns the escaped literal, only matching whole words at the start and end of the variation,
+// e.g. "Hi" does not match in "History".
+func literalPattern(literal string, first, last bool) string {
+	pattern := regexp.QuoteMeta(literal)
+
+	if literal == "" {
+		return pattern
+	}
+
+	if first && isWordByte(literal[0]) {
+		pattern = `\b` + pattern
+	}
+
+	if last && isWordByte(literal[len(literal)-1]) {
+		pattern += `\b`
+	}
+
+	return pattern
+}
+
+// isWordByte returns true for the ASCII word characters of \b.
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+func literalLen(pieces []piece) int {
+	n := 0
+	for _, p := range pieces {
+		n += len(p.literal)
+	}
+
+	return n
+}
+
+// pieces splits the translation into literals and placeholders, false if it cannot be matched.
+func pieces(text string) ([]piece, bool) {
+	tmpl, err := l10n.ParseTemplate(text)
+	if err != nil {
+		return nil, false
+	}
+
+	if named := tmpl.Named(); len(named) > 0 {
+		var out []piece
+
+		for _, p := range named {
+			i := strings.Index(text, p.String())
+			if i < 0 {
+				return nil, false
+			}
+
+			out = append(out, piece{literal: text[:i]}, piece{arg: p.Name, raw: p.String()})
+			text = text[i+len(p.String()):]
+		}
+
+		return append(out, piece{literal: text}), true
+	}
+
+	verbs := tmpl.Verbs()
+
+	var (
+		out  []piece
+		last int
+		k    int
+	)
+
+	for _, m := range verbPattern.FindAllStringIndex(text, -1) {
+		raw := text[m[0]:m[1]]
+		if raw == "%%" {
+			out = append(out, piece{literal: text[last:m[0]] + "%"})
+			last = m[1]
+
+			continue
+		}
+
+		if k >= len(verbs) || verbs[k].Verb == "*" {
+			return nil, false
+		}
+
+		out = append(out, piece{literal: text[last:m[0]]}, piece{arg: strconv.Itoa(verbs[k].Index), raw: raw})
+		last = m[1]
+		k++
+	}
+
+	if k != len(verbs) {
+		return nil, false
+	}
+
+	return append(out, piece{literal: text[last:]}), true
+}
+
+// normalize returns the decoded JSON value with the variations in its strings normalized.
+func (n *normalizer) normalize(v interface{}) interface{} {
+	if n == nil || len(n.keys) == 0 {
+		return v
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = n.normalize(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = n.normalize(e)
+		}
+	case string:
+		for _, key := range n.keys {
+			v = key.normalize(v)
+		}
+
+		return v
+	}
+
+	return v
+}
+
+// normalize replaces the variations in the text with the first variation.
+func (v *variations) normalize(text string) string {
+	return v.pattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := v.pattern.FindStringSubmatch(match)
+
+		// capture the arguments of the alternative that matched
+		args := map[string]string{}
+		group := 1
+
+		for _, groups := range v.groups {
+			if sub[group] == "" {
+				group += 1 + len(groups)
+				continue
+			}
+
+			for j, arg := range groups {
+				if _, ok := args[arg]; !ok {
+					args[arg] = sub[group+1+j]
+				}
+			}
+
+			break
+		}
+
+		var sb strings.Builder
+
+		for _, p := range v.first {
+			if p.arg == "" {
+				sb.WriteString(p.literal)
+				continue
+			}
+
+			if a, ok := args[p.arg]; ok {
+				sb.WriteString(a)
+				continue
+			}
+
+			sb.WriteString(p.raw)
+		}
+
+		return sb.String()
+	})
+}
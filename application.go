@@ -4,6 +4,8 @@ package alexa
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/drpsychick/go-alexa-lambda/l10n"
 	log "github.com/hamba/logger/v2"
@@ -27,10 +29,17 @@ type Response struct {
 }
 
 // GetLocaleWithFallback falls back to default locale which must be considered carefully.
+//
+// If the locale is not registered, a locale of the same language is used (e.g. "en-US" for "en-IN"),
+// preferring the default locale, before falling back to the default locale.
 func GetLocaleWithFallback(registry l10n.LocaleRegistry, locale string) (l10n.LocaleInstance, Response) {
 	loc, err := registry.Resolve(locale)
 	if err != nil {
-		loc = registry.GetDefault()
+		loc = resolveLanguage(registry, locale)
+		if loc == nil {
+			loc = registry.GetDefault()
+		}
+
 		if loc == nil {
 			return nil, Response{
 				Title: "Error",
@@ -43,6 +52,40 @@ func GetLocaleWithFallback(registry l10n.LocaleRegistry, locale string) (l10n.Lo
 	return loc, Response{}
 }
 
+// resolveLanguage returns a registered locale with the same language as the given locale.
+func resolveLanguage(registry l10n.LocaleRegistry, locale string) l10n.LocaleInstance {
+	lang := language(locale)
+	if lang == "" {
+		return nil
+	}
+
+	if def := registry.GetDefault(); def != nil && language(def.GetName()) == lang {
+		return def
+	}
+
+	locales := registry.GetLocales()
+
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		if language(name) == lang {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+
+	return locales[names[0]]
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
 // ResponseError defines a response error.
 type ResponseError interface {
 	error
@@ -53,9 +96,10 @@ type ResponseError interface {
 // HandleError handles default and ResponseErrors. Returns true if the error was handled.
 func HandleError(b *ResponseBuilder, loc l10n.LocaleInstance, err error) bool {
 	var (
-		resp    Response
-		respErr ResponseError
-		l10nErr l10n.LocaleError
+		resp      Response
+		respErr   ResponseError
+		unusedErr l10n.UnusedArgumentError
+		l10nErr   l10n.LocaleError
 	)
 
 	switch {
@@ -67,6 +111,9 @@ func HandleError(b *ResponseBuilder, loc l10n.LocaleInstance, err error) bool {
 		}
 	case errors.As(err, &respErr):
 		resp = respErr.Response(loc)
+	case errors.As(err, &unusedErr):
+		// not a missing placeholder, the skill passed an argument the translation does not use
+		resp = TranslationError{unusedErr.Locale, unusedErr.Key}.Response(loc)
 	case errors.As(err, &l10nErr):
 		if l10nErr.GetPlaceholder() == "" {
 			resp = Response{
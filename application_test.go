@@ -53,6 +53,7 @@ func TestGetLocaleWithFallback(t *testing.T) {
 		want1 Response
 	}{
 		{"Fallback", args{l10n.DefaultRegistry, "fr-FR"}, en, Response{}},
+		{"LanguageFallback", args{l10n.DefaultRegistry, "en-IN"}, en, Response{}},
 		{"NoFallback", args{l10n.NewRegistry(), "fr-FR"}, nil, Response{
 			Title: "Error", Text: "No locale found!", End: true,
 		}},
@@ -84,6 +85,7 @@ func TestHandleError(t *testing.T) {
 	myTransErr := TranslationError{"en-US", "foo"}
 	myNoTransErr := l10n.NoTranslationError{Locale: "en-US", Key: "foo", Placeholder: ""}
 	myNoTransPlceholderErr := l10n.NoTranslationError{Locale: "en-US", Key: "key", Placeholder: "placeholder"}
+	myUnusedArgErr := l10n.UnusedArgumentError{Locale: "en-US", Key: "key", Placeholder: "1"}
 	tests := []struct {
 		name string
 		args args
@@ -96,6 +98,7 @@ func TestHandleError(t *testing.T) {
 		{"HandleTransError", args{b, loc, myTransErr}, true},
 		{"HandleNoTransError", args{b, loc, myNoTransErr}, true},
 		{"HandlePlaceholderError", args{b, loc, myNoTransPlceholderErr}, true},
+		{"HandleUnusedArgumentError", args{b, loc, myUnusedArgErr}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +109,24 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleError_UnusedArgument(t *testing.T) {
+	loc := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		l10n.KeyErrorTranslationTitle:        {"Translation error"},
+		l10n.KeyErrorTranslationText:         {"A translation is broken."},
+		l10n.KeyErrorTranslationSSML:         {"<speak>A translation is broken.</speak>"},
+		l10n.KeyErrorMissingPlaceholderTitle: {"Missing placeholder"},
+	}}
+	b := &ResponseBuilder{}
+
+	if !HandleError(b, loc, l10n.UnusedArgumentError{Locale: "en-US", Key: "Greeting", Placeholder: "1"}) {
+		t.Fatal("HandleError() = false, want true")
+	}
+
+	if got := b.Build().Response.Card.Title; got != "Translation error" {
+		t.Errorf("HandleError() title = %q, want %q", got, "Translation error")
+	}
+}
+
 func TestTextError_Error(t *testing.T) {
 	type fields struct {
 		Locale string
@@ -0,0 +1,125 @@
+// Command alexa-dev talks to a skill served over HTTP by alexa.ListenAndServe, from typed utterances.
+//
+// Usage:
+//
+//	alexa-dev [-url http://localhost:8080] [-locale en-US] -model models/en-US.json
+//
+// Each line read from stdin is sent in one session: "launch" sends a LaunchRequest, "end" a SessionEndedRequest,
+// "reset" starts a new session and anything else is matched against the interaction model and sent as
+// IntentRequest, see alexatest.Matcher. The speech, reprompt and card of each response are printed.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda"
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	"github.com/drpsychick/go-alexa-lambda/skill"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func main() {
+	url := flag.String("url", "http://localhost:8080", "url of the skill")
+	locale := flag.String("locale", string(alexatest.DefaultLocale), "locale of the requests")
+	modelFile := flag.String("model", "", "interaction model JSON file to match utterances")
+	flag.Parse()
+
+	sim := alexatest.NewRemote(*url, alexatest.WithLocale(alexa.RequestLocale(*locale)))
+
+	if *modelFile != "" {
+		model, err := readModel(*modelFile)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		sim.WithModel(model)
+	}
+
+	if err := run(sim, os.Stdin, os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func readModel(file string) (*skill.Model, error) {
+	b, err := os.ReadFile(file) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	model := &skill.Model{}
+	if err := jsoniter.Unmarshal(b, model); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return model, nil
+}
+
+func run(sim *alexatest.Simulator, in io.Reader, out io.Writer) error {
+	sc := bufio.NewScanner(in)
+
+	_, _ = fmt.Fprint(out, "> ")
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+
+		var (
+			resp *alexa.ResponseEnvelope
+			err  error
+		)
+
+		switch line {
+		case "":
+		case "reset":
+			sim.Reset()
+		case "launch":
+			resp, err = sim.Send(alexatest.LaunchRequest())
+		case "end":
+			resp, err = sim.Send(alexatest.SessionEndedRequest("USER_INITIATED"))
+		default:
+			resp, err = sim.Say(line)
+		}
+
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(out, "error: %v\n", err)
+		case resp != nil:
+			printResponse(out, resp)
+		}
+
+		if sim.Ended() {
+			_, _ = fmt.Fprintln(out, "(session ended)")
+			sim.Reset()
+		}
+
+		_, _ = fmt.Fprint(out, "> ")
+	}
+
+	_, _ = fmt.Fprintln(out)
+
+	return sc.Err()
+}
+
+func printResponse(out io.Writer, resp *alexa.ResponseEnvelope) {
+	if s := alexatest.Speech(resp); s != "" {
+		_, _ = fmt.Fprintf(out, "alexa: %s\n", s)
+	}
+
+	if s := alexatest.Reprompt(resp); s != "" {
+		_, _ = fmt.Fprintf(out, "reprompt: %s\n", s)
+	}
+
+	if c := resp.Response.Card; c != nil {
+		_, _ = fmt.Fprintf(out, "card: %s: %s\n", c.Title, c.Content+c.Text)
+	}
+
+	for _, d := range resp.Response.Directives {
+		_, _ = fmt.Fprintf(out, "directive: %s\n", d.Type)
+	}
+}
@@ -0,0 +1,107 @@
+// Command alexa-replay replays recorded requests against a skill served over HTTP and diffs the responses.
+//
+// Usage:
+//
+//	alexa-replay [-url http://localhost:8080] [-locales dir] <recordings.jsonl>...
+//
+// The recordings are written by alexa.Recorder, one request and response per line. Each request is sent as
+// recorded and the response is compared with the recorded response, see alexatest.Diff. With the translation
+// files of the skill, variations of a translation do not differ, see alexatest.WithReplayVariations.
+// Differences are printed and the command exits with status 1 if any were found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/drpsychick/go-alexa-lambda/alexatest"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+)
+
+func main() {
+	url := flag.String("url", "http://localhost:8080", "url of the skill")
+	dir := flag.String("locales", "", "directory of the translation files of the skill")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <recordings.jsonl>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var opts []alexatest.ReplayFunc
+
+	if *dir != "" {
+		locales, err := loadLocales(*dir)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		opts = append(opts, alexatest.WithReplayVariations(locales...))
+	}
+
+	sim := alexatest.NewRemote(*url)
+	failed := false
+
+	for _, file := range flag.Args() {
+		mismatches, err := replay(sim, file, opts...)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		for _, m := range mismatches {
+			fmt.Printf("%s: %v\n", file, m)
+		}
+
+		failed = failed || len(mismatches) > 0
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// loadLocales returns the locales of the translation files in the directory.
+func loadLocales(dir string) ([]l10n.LocaleInstance, error) {
+	registry := l10n.NewRegistry()
+	if err := l10n.RegisterFS(registry, os.DirFS(dir), "."); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(registry.GetLocales()))
+	for name := range registry.GetLocales() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	locales := make([]l10n.LocaleInstance, 0, len(names))
+	for _, name := range names {
+		locales = append(locales, registry.GetLocales()[name])
+	}
+
+	return locales, nil
+}
+
+func replay(sim *alexatest.Simulator, file string, opts ...alexatest.ReplayFunc) ([]alexatest.Mismatch, error) {
+	f, err := os.Open(file) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	recs, err := alexatest.ReadRecordings(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return sim.Replay(recs, opts...)
+}
@@ -0,0 +1,95 @@
+// Command l10n-verify verifies the translation files of a directory.
+//
+// Usage:
+//
+//	l10n-verify [-default en-US] [-keys keys.txt] <dir>
+//
+// All translation files (JSON, YAML and PO) in the directory are loaded, one file per locale,
+// and compared with l10n.Registry.Verify. The keys file lists the keys used by the skill, one per line.
+// Errors are printed and the command exits with status 1 if any were found.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+)
+
+func main() {
+	defaultLocale := flag.String("default", "", "locale to compare placeholders with")
+	keysFile := flag.String("keys", "", "file with the keys used, one per line")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <dir>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	errs, err := run(flag.Arg(0), *defaultLocale, *keysFile)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+func run(dir, defaultLocale, keysFile string) ([]error, error) {
+	registry := l10n.NewRegistry().(*l10n.Registry)
+	if err := l10n.RegisterFS(registry, os.DirFS(dir), "."); err != nil {
+		return nil, err
+	}
+
+	if defaultLocale != "" {
+		if err := registry.SetDefault(defaultLocale); err != nil {
+			return nil, err
+		}
+	}
+
+	var opts []l10n.VerifyFunc
+
+	if keysFile != "" {
+		keys, err := readKeys(keysFile)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, l10n.WithKeys(keys...))
+	}
+
+	return registry.Verify(opts...), nil
+}
+
+func readKeys(file string) ([]string, error) {
+	f, err := os.Open(file) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	var keys []string
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if k := strings.TrimSpace(sc.Text()); k != "" && !strings.HasPrefix(k, "#") {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys, sc.Err()
+}
@@ -0,0 +1,142 @@
+package l10n
+
+// chainedLocale is a scope of a locale looking up keys missing in the locale along its fallbacks,
+// see Registry.Scope.
+type chainedLocale struct {
+	LocaleInstance
+
+	fallbacks []LocaleInstance
+}
+
+// fallback returns the first fallback having a translation for a key missing in the locale.
+func (c *chainedLocale) fallback(key string) (LocaleInstance, bool) {
+	if hasKey(c.LocaleInstance, key) {
+		return nil, false
+	}
+
+	for _, fb := range c.fallbacks {
+		if hasKey(fb, key) {
+			return fb, true
+		}
+	}
+
+	return nil, false
+}
+
+// has returns true if the locale or one of its fallbacks has a translation for the key.
+func (c *chainedLocale) has(key string) bool {
+	if hasKey(c.LocaleInstance, key) {
+		return true
+	}
+
+	_, ok := c.fallback(key)
+
+	return ok
+}
+
+// hasKey returns true if the locale has a translation for the key.
+//
+// Locales not listing their translations (see Locale.GetSnippets) are assumed to translate every key.
+func hasKey(loc LocaleInstance, key string) bool {
+	switch l := loc.(type) {
+	case *Locale:
+		return l.has(key)
+	case *scopedLocale:
+		return l.has(key)
+	case interface{ GetSnippets() Snippets }:
+		return len(l.GetSnippets()[key]) > 0
+	default:
+		return true
+	}
+}
+
+// Get returns the first translation of the locale or its fallbacks.
+func (c *chainedLocale) Get(key string, args ...interface{}) string {
+	fb, ok := c.fallback(key)
+	if !ok {
+		return c.LocaleInstance.Get(key, args...)
+	}
+
+	if l, ok := fb.(*Locale); ok {
+		t, errs := l.get(key, args...)
+		c.appendErrors(errs)
+
+		return t
+	}
+
+	return fb.Get(key, args...)
+}
+
+// GetAny returns a random translation of the locale or its fallbacks.
+func (c *chainedLocale) GetAny(key string, args ...interface{}) string {
+	fb, ok := c.fallback(key)
+	if !ok {
+		return c.LocaleInstance.GetAny(key, args...)
+	}
+
+	if l, ok := fb.(*Locale); ok {
+		var h History
+		if s, ok := c.LocaleInstance.(*scopedLocale); ok {
+			h = s.history
+		}
+
+		t, errs := l.getAny(key, h, args...)
+		c.appendErrors(errs)
+
+		return t
+	}
+
+	return fb.GetAny(key, args...)
+}
+
+// GetAll returns all translations of the locale or its fallbacks.
+func (c *chainedLocale) GetAll(key string, args ...interface{}) []string {
+	fb, ok := c.fallback(key)
+	if !ok {
+		return c.LocaleInstance.GetAll(key, args...)
+	}
+
+	if l, ok := fb.(*Locale); ok {
+		t, errs := l.getAll(key, args...)
+		c.appendErrors(errs)
+
+		return t
+	}
+
+	return fb.GetAll(key, args...)
+}
+
+// GetPlural returns the first translation of the plural form of n of the locale or its fallbacks.
+func (c *chainedLocale) GetPlural(key string, n interface{}, args ...interface{}) string {
+	op, err := NewPluralOperands(n)
+	if err != nil {
+		c.appendErrors([]error{ValidationError{c.GetName(), key, err.Error()}})
+		return ""
+	}
+
+	keys := pluralKeys(key, op, PluralRuleFor(c.GetName())(op))
+	for _, k := range keys {
+		if c.has(k) {
+			return c.Get(k, args...)
+		}
+	}
+
+	return c.Get(keys[len(keys)-1], args...)
+}
+
+// Scope returns a new scope of the locale with the same fallbacks.
+func (c *chainedLocale) Scope() LocaleInstance {
+	return &chainedLocale{LocaleInstance: Scope(c.LocaleInstance), fallbacks: c.fallbacks}
+}
+
+// WithHistory returns an instance using the history with the same fallbacks.
+func (c *chainedLocale) WithHistory(h History) LocaleInstance {
+	return &chainedLocale{LocaleInstance: WithHistory(c.LocaleInstance, h), fallbacks: c.fallbacks}
+}
+
+// appendErrors collects the errors of lookups in fallbacks in the scope of the locale.
+func (c *chainedLocale) appendErrors(errs []error) {
+	if a, ok := c.LocaleInstance.(interface{ appendErrors(errs []error) }); ok {
+		a.appendErrors(errs)
+	}
+}
@@ -0,0 +1,473 @@
+package l10n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drpsychick/go-alexa-lambda/ssml"
+)
+
+// Time units of durations and relative times.
+const (
+	UnitSecond string = "second"
+	UnitMinute string = "minute"
+	UnitHour   string = "hour"
+	UnitDay    string = "day"
+)
+
+// UnitForms are the forms of a unit by plural category, "%d" is replaced by the count.
+type UnitForms map[PluralCategory]string
+
+// FormatData defines how numbers, dates, durations and lists are formatted in a locale.
+type FormatData struct {
+	DecimalSeparator string
+	GroupSeparator   string
+	// MinGroupingDigits is the minimum number of digits before the first group separator is used.
+	MinGroupingDigits int
+
+	// Ordinal returns the ordinal of n, e.g. "1st".
+	Ordinal func(n int) string
+	// Day returns the day of month used as {day} in dates, e.g. "1er", defaults to the number.
+	Day func(n int) string
+
+	Months [12]string
+	// DatePattern is a template with the placeholders {day}, {ordinal}, {month}, {monthNumber} and {year}.
+	DatePattern string
+
+	// Units are the forms of UnitSecond, UnitMinute, UnitHour and UnitDay used in durations.
+	Units map[string]UnitForms
+	// RelativeUnits are the unit forms used in relative times, defaults to Units.
+	RelativeUnits map[string]UnitForms
+	// Future and Past are the relative time patterns, "%s" is replaced by the time, e.g. "in %s".
+	Future string
+	Past   string
+	Now    string
+
+	// ListSeparator joins list items, ListLastSeparator the last two and ListPairSeparator a list of two.
+	ListSeparator     string
+	ListLastSeparator string
+	ListPairSeparator string
+}
+
+var (
+	formatMu   sync.RWMutex
+	formatData = map[string]FormatData{
+		"en":    formatEnglish(", and ", "{month} {ordinal}, {year}"),
+		"en-AU": formatEnglish(" and ", "{ordinal} of {month} {year}"),
+		"en-CA": formatEnglish(" and ", "{month} {ordinal}, {year}"),
+		"en-GB": formatEnglish(" and ", "{ordinal} of {month} {year}"),
+		"en-IN": formatEnglish(" and ", "{ordinal} of {month} {year}"),
+		"de":    formatGerman(),
+		"fr":    formatFrench(),
+		"it":    formatItalian(),
+		"es":    formatSpanish(),
+		"ja":    formatJapanese(),
+	}
+)
+
+// RegisterFormat sets the format data of a language (e.g. "de") or a specific locale (e.g. "de-CH").
+func RegisterFormat(locale string, data FormatData) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+
+	formatData[locale] = data
+}
+
+// FormatDataFor returns the format data of the locale, falling back to its language and English.
+func FormatDataFor(locale string) FormatData {
+	formatMu.RLock()
+	defer formatMu.RUnlock()
+
+	if d, ok := formatData[locale]; ok {
+		return d
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+	if d, ok := formatData[lang]; ok {
+		return d
+	}
+
+	return formatData["en"]
+}
+
+// Formatter formats values for a locale.
+type Formatter struct {
+	locale string
+	data   FormatData
+	speech bool
+}
+
+// FormatterFunc is an option of a Formatter.
+type FormatterFunc func(f *Formatter)
+
+// WithSayAs formats numbers, ordinals and dates as SSML say-as markup for speech.
+func WithSayAs() FormatterFunc {
+	return func(f *Formatter) {
+		f.speech = true
+	}
+}
+
+// NewFormatter returns a formatter for the locale.
+func NewFormatter(locale string, opts ...FormatterFunc) *Formatter {
+	f := &Formatter{locale: locale, data: FormatDataFor(locale)}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Number formats v with the given number of decimals, -1 uses as many as needed.
+func (f *Formatter) Number(v float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+
+	sign := ""
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		sign = "-"
+	}
+
+	// say-as expects the plain number with a dot as decimal separator in every locale
+	if f.speech {
+		return ssml.SayAs(ssml.SayAsInterpretAsCardinal, "", sign+s)
+	}
+
+	intPart, frac, _ := strings.Cut(s, ".")
+	intPart = f.group(intPart)
+
+	if frac != "" {
+		intPart += f.data.DecimalSeparator + frac
+	}
+
+	return sign + intPart
+}
+
+func (f *Formatter) group(digits string) string {
+	if f.data.GroupSeparator == "" || len(digits) < 4 || len(digits) < 4+f.data.MinGroupingDigits-1 {
+		return digits
+	}
+
+	var sb strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(f.data.GroupSeparator)
+		}
+
+		sb.WriteRune(c)
+	}
+
+	return sb.String()
+}
+
+// Ordinal formats n as ordinal number, e.g. "1st".
+func (f *Formatter) Ordinal(n int) string {
+	if f.speech {
+		return ssml.SayAs(ssml.SayAsInterpretAsOrdinal, "", strconv.Itoa(n))
+	}
+
+	return f.data.Ordinal(n)
+}
+
+// Date formats the date of t, e.g. "March 1st, 2024".
+func (f *Formatter) Date(t time.Time) string {
+	if f.speech {
+		return ssml.SayAs(ssml.SayAsInterpretAsDate, "ymd", t.Format("20060102"))
+	}
+
+	day := strconv.Itoa(t.Day())
+	if f.data.Day != nil {
+		day = f.data.Day(t.Day())
+	}
+
+	tmpl, _ := ParseTemplate(f.data.DatePattern)
+	s, _ := tmpl.Execute("", Args{
+		"day":         day,
+		"ordinal":     f.data.Ordinal(t.Day()),
+		"month":       f.data.Months[t.Month()-1],
+		"monthNumber": int(t.Month()),
+		"year":        t.Year(),
+	})
+
+	return s
+}
+
+// Duration formats d in days, hours, minutes and seconds, e.g. "1 hour and 5 minutes".
+func (f *Formatter) Duration(d time.Duration) string {
+	parts := f.units(d, f.data.Units)
+	if len(parts) == 0 {
+		return f.unit(UnitSecond, 0, f.data.Units)
+	}
+
+	return f.List(parts)
+}
+
+// RelativeTime formats d relative to now in its largest unit, e.g. "in 3 days" or "2 hours ago".
+func (f *Formatter) RelativeTime(d time.Duration) string {
+	units := f.data.RelativeUnits
+	if units == nil {
+		units = f.data.Units
+	}
+
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+
+	var s string
+
+	switch {
+	case abs < time.Second:
+		return f.data.Now
+	case abs < time.Minute:
+		s = f.unit(UnitSecond, int(abs/time.Second), units)
+	case abs < time.Hour:
+		s = f.unit(UnitMinute, int(abs/time.Minute), units)
+	case abs < 24*time.Hour:
+		s = f.unit(UnitHour, int(abs/time.Hour), units)
+	default:
+		s = f.unit(UnitDay, int(abs/(24*time.Hour)), units)
+	}
+
+	if d < 0 {
+		return strings.Replace(f.data.Past, "%s", s, 1)
+	}
+
+	return strings.Replace(f.data.Future, "%s", s, 1)
+}
+
+// List joins the items as conjunction, e.g. "apples, pears and plums".
+func (f *Formatter) List(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + f.data.ListPairSeparator + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], f.data.ListSeparator) + f.data.ListLastSeparator + items[len(items)-1]
+	}
+}
+
+func (f *Formatter) units(d time.Duration, units map[string]UnitForms) []string {
+	if d < 0 {
+		d = -d
+	}
+
+	var parts []string
+
+	for _, u := range []struct {
+		name string
+		size time.Duration
+	}{
+		{UnitDay, 24 * time.Hour},
+		{UnitHour, time.Hour},
+		{UnitMinute, time.Minute},
+		{UnitSecond, time.Second},
+	} {
+		if n := int(d / u.size); n > 0 {
+			parts = append(parts, f.unit(u.name, n, units))
+			d -= time.Duration(n) * u.size
+		}
+	}
+
+	return parts
+}
+
+func (f *Formatter) unit(name string, n int, units map[string]UnitForms) string {
+	cat, _ := PluralCategoryOf(f.locale, n)
+
+	form, ok := units[name][cat]
+	if !ok {
+		form = units[name][PluralOther]
+	}
+
+	return strings.Replace(form, "%d", strconv.Itoa(n), 1)
+}
+
+func formatEnglish(last, datePattern string) FormatData {
+	return FormatData{
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		Ordinal: func(n int) string {
+			suffix := "th"
+			switch {
+			case n%100 >= 11 && n%100 <= 13:
+			case n%10 == 1:
+				suffix = "st"
+			case n%10 == 2:
+				suffix = "nd"
+			case n%10 == 3:
+				suffix = "rd"
+			}
+
+			return strconv.Itoa(n) + suffix
+		},
+		Months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		DatePattern: datePattern,
+		Units: map[string]UnitForms{
+			UnitSecond: {PluralOne: "%d second", PluralOther: "%d seconds"},
+			UnitMinute: {PluralOne: "%d minute", PluralOther: "%d minutes"},
+			UnitHour:   {PluralOne: "%d hour", PluralOther: "%d hours"},
+			UnitDay:    {PluralOne: "%d day", PluralOther: "%d days"},
+		},
+		Future:            "in %s",
+		Past:              "%s ago",
+		Now:               "now",
+		ListSeparator:     ", ",
+		ListLastSeparator: last,
+		ListPairSeparator: " and ",
+	}
+}
+
+func formatGerman() FormatData {
+	units := map[string]UnitForms{
+		UnitSecond: {PluralOne: "%d Sekunde", PluralOther: "%d Sekunden"},
+		UnitMinute: {PluralOne: "%d Minute", PluralOther: "%d Minuten"},
+		UnitHour:   {PluralOne: "%d Stunde", PluralOther: "%d Stunden"},
+		UnitDay:    {PluralOne: "%d Tag", PluralOther: "%d Tage"},
+	}
+
+	return FormatData{
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		Ordinal:          func(n int) string { return strconv.Itoa(n) + "." },
+		Months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		DatePattern: "{ordinal} {month} {year}",
+		Units:       units,
+		RelativeUnits: map[string]UnitForms{
+			UnitSecond: units[UnitSecond],
+			UnitMinute: units[UnitMinute],
+			UnitHour:   units[UnitHour],
+			UnitDay:    {PluralOne: "%d Tag", PluralOther: "%d Tagen"},
+		},
+		Future:            "in %s",
+		Past:              "vor %s",
+		Now:               "jetzt",
+		ListSeparator:     ", ",
+		ListLastSeparator: " und ",
+		ListPairSeparator: " und ",
+	}
+}
+
+func formatFrench() FormatData {
+	return FormatData{
+		DecimalSeparator: ",",
+		GroupSeparator:   " ",
+		Ordinal: func(n int) string {
+			if n == 1 {
+				return "1er"
+			}
+
+			return strconv.Itoa(n) + "e"
+		},
+		Day: func(n int) string {
+			if n == 1 {
+				return "1er"
+			}
+
+			return strconv.Itoa(n)
+		},
+		Months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		DatePattern: "{day} {month} {year}",
+		Units: map[string]UnitForms{
+			UnitSecond: {PluralOne: "%d seconde", PluralOther: "%d secondes"},
+			UnitMinute: {PluralOne: "%d minute", PluralOther: "%d minutes"},
+			UnitHour:   {PluralOne: "%d heure", PluralOther: "%d heures"},
+			UnitDay:    {PluralOne: "%d jour", PluralOther: "%d jours"},
+		},
+		Future:            "dans %s",
+		Past:              "il y a %s",
+		Now:               "maintenant",
+		ListSeparator:     ", ",
+		ListLastSeparator: " et ",
+		ListPairSeparator: " et ",
+	}
+}
+
+func formatItalian() FormatData {
+	return FormatData{
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		Ordinal:          func(n int) string { return strconv.Itoa(n) + "º" },
+		Months: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+		DatePattern: "{day} {month} {year}",
+		Units: map[string]UnitForms{
+			UnitSecond: {PluralOne: "%d secondo", PluralOther: "%d secondi"},
+			UnitMinute: {PluralOne: "%d minuto", PluralOther: "%d minuti"},
+			UnitHour:   {PluralOne: "%d ora", PluralOther: "%d ore"},
+			UnitDay:    {PluralOne: "%d giorno", PluralOther: "%d giorni"},
+		},
+		Future:            "tra %s",
+		Past:              "%s fa",
+		Now:               "ora",
+		ListSeparator:     ", ",
+		ListLastSeparator: " e ",
+		ListPairSeparator: " e ",
+	}
+}
+
+func formatSpanish() FormatData {
+	return FormatData{
+		DecimalSeparator:  ",",
+		GroupSeparator:    ".",
+		MinGroupingDigits: 2,
+		Ordinal:           func(n int) string { return strconv.Itoa(n) + ".º" },
+		Months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		DatePattern: "{day} de {month} de {year}",
+		Units: map[string]UnitForms{
+			UnitSecond: {PluralOne: "%d segundo", PluralOther: "%d segundos"},
+			UnitMinute: {PluralOne: "%d minuto", PluralOther: "%d minutos"},
+			UnitHour:   {PluralOne: "%d hora", PluralOther: "%d horas"},
+			UnitDay:    {PluralOne: "%d día", PluralOther: "%d días"},
+		},
+		Future:            "dentro de %s",
+		Past:              "hace %s",
+		Now:               "ahora",
+		ListSeparator:     ", ",
+		ListLastSeparator: " y ",
+		ListPairSeparator: " y ",
+	}
+}
+
+func formatJapanese() FormatData {
+	return FormatData{
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		Ordinal:          func(n int) string { return strconv.Itoa(n) + "番目" },
+		Months: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		DatePattern: "{year}年{monthNumber}月{day}日",
+		Units: map[string]UnitForms{
+			UnitSecond: {PluralOther: "%d秒"},
+			UnitMinute: {PluralOther: "%d分"},
+			UnitHour:   {PluralOther: "%d時間"},
+			UnitDay:    {PluralOther: "%d日"},
+		},
+		Future:            "%s後",
+		Past:              "%s前",
+		Now:               "今",
+		ListSeparator:     "、",
+		ListLastSeparator: "、",
+		ListPairSeparator: "、",
+	}
+}
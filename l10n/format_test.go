@@ -0,0 +1,123 @@
+package l10n_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatter_Number(t *testing.T) {
+	tests := []struct {
+		locale   string
+		v        float64
+		decimals int
+		want     string
+	}{
+		{"en-US", 1234567.5, -1, "1,234,567.5"},
+		{"de-DE", 3.5, -1, "3,5"},
+		{"de-DE", 1234.5, 2, "1.234,50"},
+		{"fr-FR", 1234.5, -1, "1\u202f234,5"},
+		{"es-ES", 1234, 0, "1234"},
+		{"es-ES", 12345, 0, "12.345"},
+		{"ja-JP", -1234, 0, "-1,234"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, l10n.NewFormatter(tt.locale).Number(tt.v, tt.decimals), tt.locale)
+	}
+
+	f := l10n.NewFormatter("de-DE", l10n.WithSayAs())
+	assert.Equal(t, `<say-as interpret-as="cardinal">1234.5</say-as>`, f.Number(1234.5, -1))
+	assert.Equal(t, `<say-as interpret-as="cardinal">-3.50</say-as>`, f.Number(-3.5, 2))
+}
+
+func TestFormatter_Ordinal(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{"en-US", 1, "1st"},
+		{"en-US", 2, "2nd"},
+		{"en-US", 3, "3rd"},
+		{"en-US", 11, "11th"},
+		{"en-US", 22, "22nd"},
+		{"de-DE", 3, "3."},
+		{"fr-FR", 1, "1er"},
+		{"fr-FR", 2, "2e"},
+		{"es-ES", 2, "2.º"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, l10n.NewFormatter(tt.locale).Ordinal(tt.n), tt.locale)
+	}
+
+	f := l10n.NewFormatter("en-US", l10n.WithSayAs())
+	assert.Equal(t, `<say-as interpret-as="ordinal">2</say-as>`, f.Ordinal(2))
+}
+
+func TestFormatter_Date(t *testing.T) {
+	d := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"en-US": "March 1st, 2024",
+		"en-GB": "1st of March 2024",
+		"de-DE": "1. März 2024",
+		"fr-FR": "1er mars 2024",
+		"it-IT": "1 marzo 2024",
+		"es-MX": "1 de marzo de 2024",
+		"ja-JP": "2024年3月1日",
+	}
+
+	for locale, want := range tests {
+		assert.Equal(t, want, l10n.NewFormatter(locale).Date(d), locale)
+	}
+
+	assert.Equal(t, "2 mars 2024", l10n.NewFormatter("fr-FR").Date(d.AddDate(0, 0, 1)))
+
+	f := l10n.NewFormatter("en-GB", l10n.WithSayAs())
+	assert.Equal(t, `<say-as interpret-as="date" format="ymd">20240301</say-as>`, f.Date(d))
+}
+
+func TestFormatter_Duration(t *testing.T) {
+	d := time.Hour + 5*time.Minute + 3*time.Second
+
+	assert.Equal(t, "1 hour, 5 minutes, and 3 seconds", l10n.NewFormatter("en-US").Duration(d))
+	assert.Equal(t, "1 Stunde, 5 Minuten und 3 Sekunden", l10n.NewFormatter("de-DE").Duration(d))
+	assert.Equal(t, "2 Tage", l10n.NewFormatter("de-DE").Duration(48*time.Hour))
+	assert.Equal(t, "0 seconds", l10n.NewFormatter("en-GB").Duration(0))
+}
+
+func TestFormatter_RelativeTime(t *testing.T) {
+	tests := []struct {
+		locale string
+		d      time.Duration
+		want   string
+	}{
+		{"en-US", 72 * time.Hour, "in 3 days"},
+		{"en-US", -2 * time.Hour, "2 hours ago"},
+		{"en-US", 0, "now"},
+		{"de-DE", 72 * time.Hour, "in 3 Tagen"},
+		{"de-DE", -time.Minute, "vor 1 Minute"},
+		{"fr-FR", -30 * time.Second, "il y a 30 secondes"},
+		{"it-IT", time.Hour, "tra 1 ora"},
+		{"es-ES", -24 * time.Hour, "hace 1 día"},
+		{"ja-JP", 3 * time.Minute, "3分後"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, l10n.NewFormatter(tt.locale).RelativeTime(tt.d), tt.locale)
+	}
+}
+
+func TestFormatter_List(t *testing.T) {
+	items := []string{"apples", "pears", "plums"}
+
+	assert.Equal(t, "apples, pears and plums", l10n.NewFormatter("en-GB").List(items))
+	assert.Equal(t, "apples, pears, and plums", l10n.NewFormatter("en-US").List(items))
+	assert.Equal(t, "apples and pears", l10n.NewFormatter("en-US").List(items[:2]))
+	assert.Equal(t, "apples", l10n.NewFormatter("en-US").List(items[:1]))
+	assert.Equal(t, "Äpfel, Birnen und Pflaumen", l10n.NewFormatter("de-DE").List([]string{"Äpfel", "Birnen", "Pflaumen"}))
+	assert.Empty(t, l10n.NewFormatter("fr-FR").List(nil))
+}
@@ -0,0 +1,73 @@
+package l10n
+
+// HistoryAttribute is the session attribute a SessionHistory is stored in.
+const HistoryAttribute = "l10n_history"
+
+// History remembers the variation of a key used last time, so GetAny can avoid repeating it.
+type History interface {
+	Last(key string) (int, bool)
+	Remember(key string, variation int)
+}
+
+// MapHistory is a History in memory.
+type MapHistory map[string]int
+
+// Last returns the variation of the key used last time.
+func (h MapHistory) Last(key string) (int, bool) {
+	v, ok := h[key]
+	return v, ok
+}
+
+// Remember remembers the variation used for the key.
+func (h MapHistory) Remember(key string, variation int) {
+	h[key] = variation
+}
+
+// sessionHistory is a History in session attributes.
+type sessionHistory map[string]interface{}
+
+// SessionHistory returns a History stored in the session attributes under HistoryAttribute.
+//
+// The attributes must not be nil, they are returned with the response to remember the history across turns.
+func SessionHistory(attrs map[string]interface{}) History {
+	return sessionHistory(attrs)
+}
+
+// Last returns the variation of the key used last time.
+func (h sessionHistory) Last(key string) (int, bool) {
+	m, _ := h[HistoryAttribute].(map[string]interface{})
+
+	// numbers are float64 after decoding the session attributes
+	switch v := m[key].(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// Remember remembers the variation used for the key.
+func (h sessionHistory) Remember(key string, variation int) {
+	m, ok := h[HistoryAttribute].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		h[HistoryAttribute] = m
+	}
+
+	m[key] = variation
+}
+
+// WithHistory returns an instance of the locale whose GetAny avoids the variation used last time for a key.
+//
+// If the locale does not support histories, the locale itself is returned.
+func WithHistory(loc LocaleInstance, h History) LocaleInstance {
+	if hl, ok := loc.(interface {
+		WithHistory(h History) LocaleInstance
+	}); ok {
+		return hl.WithHistory(h)
+	}
+
+	return loc
+}
@@ -0,0 +1,86 @@
+package l10n_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func newGreetings() *l10n.Locale {
+	l := l10n.NewLocale("en-US")
+	l.Set(Greeting, []string{"Hi", "Hello", "Hey"})
+
+	return l
+}
+
+func TestLocale_SetRandSource(t *testing.T) {
+	a, b := newGreetings(), newGreetings()
+	a.SetRandSource(rand.NewSource(42))
+	b.SetRandSource(rand.NewSource(42))
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a.GetAny(Greeting), b.GetAny(Greeting))
+	}
+}
+
+func TestLocale_WithHistory(t *testing.T) {
+	l := newGreetings()
+	l.SetRandSource(rand.NewSource(1))
+
+	h := l10n.MapHistory{}
+	loc := l10n.WithHistory(l, h)
+
+	last := loc.GetAny(Greeting)
+	for i := 0; i < 20; i++ {
+		got := loc.GetAny(Greeting)
+		assert.NotEqual(t, last, got)
+		last = got
+	}
+	assert.Contains(t, h, Greeting)
+}
+
+func TestLocale_WithHistorySingleVariation(t *testing.T) {
+	l := l10n.NewLocale("en-US")
+	l.Set("Bye", []string{"Bye"})
+
+	h := l10n.MapHistory{}
+	loc := l10n.WithHistory(l, h)
+
+	assert.Equal(t, "Bye", loc.GetAny("Bye"))
+	assert.Empty(t, h)
+}
+
+func TestLocale_WithHistoryScope(t *testing.T) {
+	l := newGreetings()
+	scope := l.Scope()
+
+	loc := l10n.WithHistory(scope, l10n.MapHistory{})
+	loc.GetAny("missing")
+
+	assert.Len(t, scope.GetErrors(), 1)
+	assert.Empty(t, l.GetErrors())
+}
+
+func TestSessionHistory(t *testing.T) {
+	attrs := map[string]interface{}{}
+	h := l10n.SessionHistory(attrs)
+
+	_, ok := h.Last(Greeting)
+	assert.False(t, ok)
+
+	h.Remember(Greeting, 2)
+	assert.Equal(t, map[string]interface{}{Greeting: 2}, attrs[l10n.HistoryAttribute])
+
+	// session attributes decoded from JSON
+	attrs[l10n.HistoryAttribute] = map[string]interface{}{Greeting: float64(1)}
+	v, ok := h.Last(Greeting)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	loc := l10n.WithHistory(newGreetings(), h)
+	for i := 0; i < 10; i++ {
+		assert.NotEqual(t, "Hello", loc.GetAny(Greeting))
+		attrs[l10n.HistoryAttribute] = map[string]interface{}{Greeting: float64(1)}
+	}
+}
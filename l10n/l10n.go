@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync"
 )
 
 // Default keys.
@@ -177,15 +178,32 @@ func AsDefault() RegisterFunc {
 	}
 }
 
-// Registry is the Locale registry.
+// FallbackFor registers the given Locale as the fallback for a language (e.g. "en") or locale (e.g. "en-AU").
+//
+// Unregistered locales of the language resolve to the fallback, and registered locales
+// of the language look up missing keys in the fallback.
+func FallbackFor(language string) RegisterFunc {
+	return func(cfg *Config) {
+		cfg.FallbackFor = language
+	}
+}
+
+// Registry is the Locale registry, it is safe for concurrent use.
 type Registry struct {
+	mu            sync.RWMutex
 	defaultLocale string
 	locales       map[string]LocaleInstance
+	fallbacks     map[string]string
+	chains        map[string][]LocaleInstance
 }
 
 // NewRegistry returns an empty Registry.
 func NewRegistry() LocaleRegistry {
-	return &Registry{locales: map[string]LocaleInstance{}}
+	return &Registry{
+		locales:   map[string]LocaleInstance{},
+		fallbacks: map[string]string{},
+		chains:    map[string][]LocaleInstance{},
+	}
 }
 
 // Register registers a new Locale in the DefaultRegistry.
@@ -219,6 +237,9 @@ func (r *Registry) Register(l LocaleInstance, opts ...RegisterFunc) error {
 		return errors.New("cannot register locale with no name")
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if _, ok := r.locales[l.GetName()]; ok {
 		return fmt.Errorf("locale %s already registered", l.GetName())
 	}
@@ -229,6 +250,14 @@ func (r *Registry) Register(l LocaleInstance, opts ...RegisterFunc) error {
 		opt(&cfg)
 	}
 
+	if cfg.FallbackFor != "" {
+		if fb, ok := r.fallbacks[cfg.FallbackFor]; ok {
+			return fmt.Errorf("locale %s already registered as fallback for '%s'", fb, cfg.FallbackFor)
+		}
+
+		r.fallbacks[cfg.FallbackFor] = l.GetName()
+	}
+
 	// set locale as default
 	if cfg.DefaultLocale || r.defaultLocale == "" {
 		r.defaultLocale = l.GetName()
@@ -236,45 +265,170 @@ func (r *Registry) Register(l LocaleInstance, opts ...RegisterFunc) error {
 
 	r.locales[l.GetName()] = l
 
+	r.linkFallbacks()
+
+	return nil
+}
+
+// linkFallbacks builds the chain of fallbacks of each locale for key lookups, see Registry.Scope.
+//
+// Keys are looked up along the fallbacks of the locale or its language and finally in the default locale,
+// e.g. "de-AT" > "de-DE" > "en-US". The default locale ends the chain of every locale having a fallback
+// or being one, locales without fallbacks only use their own keys.
+func (r *Registry) linkFallbacks() {
+	isFallback := make(map[string]bool, len(r.fallbacks))
+	for _, name := range r.fallbacks {
+		isFallback[name] = true
+	}
+
+	r.chains = make(map[string][]LocaleInstance, len(r.locales))
+
+	for name := range r.locales {
+		var chain []LocaleInstance
+
+		seen := map[string]bool{name: true}
+		for fb := r.fallbackFor(name); fb != nil && !seen[fb.GetName()]; fb = r.fallbackFor(fb.GetName()) {
+			seen[fb.GetName()] = true
+			chain = append(chain, fb)
+		}
+
+		def, ok := r.locales[r.defaultLocale]
+		if ok && !seen[r.defaultLocale] && (len(chain) > 0 || isFallback[name]) {
+			chain = append(chain, def)
+		}
+
+		if len(chain) > 0 {
+			r.chains[name] = chain
+		}
+	}
+}
+
+// fallbackFor returns the fallback registered for the locale or its language.
+func (r *Registry) fallbackFor(locale string) LocaleInstance {
+	if fb, ok := r.fallbacks[locale]; ok {
+		return r.locales[fb]
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+	if fb, ok := r.fallbacks[lang]; ok {
+		return r.locales[fb]
+	}
+
 	return nil
 }
 
 // GetDefault returns the default locale.
 func (r *Registry) GetDefault() LocaleInstance {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return r.locales[r.defaultLocale]
 }
 
 // SetDefault sets the default locale which must be registered.
 func (r *Registry) SetDefault(locale string) error {
-	if _, err := r.Resolve(locale); err != nil {
-		return err
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.locales[locale]; !ok {
+		return fmt.Errorf("locale '%s' not found", locale)
 	}
 
 	r.defaultLocale = locale
+	r.linkFallbacks()
 
 	return nil
 }
 
-// GetLocales returns all registered locales.
+// GetLocales returns a copy of all registered locales.
 func (r *Registry) GetLocales() map[string]LocaleInstance {
-	return r.locales
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	locales := make(map[string]LocaleInstance, len(r.locales))
+	for n, l := range r.locales {
+		locales[n] = l
+	}
+
+	return locales
 }
 
-// Resolve returns the Locale matching the given name or an error.
+// Resolve returns the Locale matching the given name, its fallback or an error.
 func (r *Registry) Resolve(locale string) (LocaleInstance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	l, ok := r.locales[locale]
 	if !ok {
+		if fb := r.fallbackFor(locale); fb != nil {
+			return fb, nil
+		}
+
 		return nil, fmt.Errorf("locale '%s' not found", locale)
 	}
 
 	return l, nil
 }
 
+// Scope returns an instance of the locale collecting its own errors (see Scope),
+// looking up keys missing in the locale along its fallbacks in the registry, see FallbackFor.
+func (r *Registry) Scope(loc LocaleInstance) LocaleInstance {
+	r.mu.RLock()
+	chain := r.chains[loc.GetName()]
+	r.mu.RUnlock()
+
+	if len(chain) == 0 {
+		return Scope(loc)
+	}
+
+	return &chainedLocale{LocaleInstance: Scope(loc), fallbacks: chain}
+}
+
+// RegistryScoper is implemented by registries that scope locales with their fallbacks, see Registry.Scope.
+type RegistryScoper interface {
+	Scope(loc LocaleInstance) LocaleInstance
+}
+
+// ScopeIn returns an instance of the locale for a single request, using the key fallbacks of the registry.
+//
+// If the registry does not implement RegistryScoper, the locale is scoped without fallbacks, see Scope.
+func ScopeIn(registry LocaleRegistry, loc LocaleInstance) LocaleInstance {
+	if s, ok := registry.(RegistryScoper); ok {
+		return s.Scope(loc)
+	}
+
+	return Scope(loc)
+}
+
+// Scoper is implemented by locales that can create a request scoped instance.
+type Scoper interface {
+	Scope() LocaleInstance
+}
+
+// Scope returns an instance of the locale that collects its own errors, e.g. for a single request.
+//
+// If the locale does not implement Scoper, the locale itself is returned.
+func Scope(loc LocaleInstance) LocaleInstance {
+	if s, ok := loc.(Scoper); ok {
+		return s.Scope()
+	}
+
+	return loc
+}
+
 // Locale is a representation of keys in a specific language.
+//
+// A Locale is safe for concurrent use, but errors are collected across all lookups.
+// Use Scope to collect the errors of a single request.
 type Locale struct {
 	Name         string // de-DE, en-US, ...
 	TextSnippets Snippets
+	mu           sync.RWMutex
 	errors       []error
+	tmplMu       sync.Mutex
+	templates    map[string][]*Template
+	rndMu        sync.Mutex
+	rnd          *rand.Rand
 }
 
 // NewLocale creates a new, empty locale.
@@ -292,83 +446,409 @@ func (l *Locale) GetName() string {
 
 // Set sets the translations for a key.
 func (l *Locale) Set(key string, values []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.TextSnippets == nil {
+		l.TextSnippets = Snippets{}
+	}
+
 	l.TextSnippets[key] = values
+
+	l.tmplMu.Lock()
+	defer l.tmplMu.Unlock()
+
+	if l.templates == nil {
+		l.templates = map[string][]*Template{}
+	}
+
+	l.templates[key] = parseTemplates(values)
 }
 
-// Get returns the first translation.
-func (l *Locale) Get(key string, args ...interface{}) string {
-	t, err := l.TextSnippets.GetFirst(key, args...)
-	if err != nil {
-		var locaerr NoTranslationError
-		switch {
-		case errors.As(err, &locaerr):
-			locaerr.Locale = l.GetName()
-			l.errors = append(l.errors, locaerr)
-		default:
-			l.errors = append(l.errors, err)
-		}
+func parseTemplates(values []string) []*Template {
+	tmpls := make([]*Template, len(values))
+	for i, v := range values {
+		tmpls[i], _ = ParseTemplate(v)
+	}
+
+	return tmpls
+}
+
+// SetSnippets replaces all translations, e.g. when reloading translation files.
+func (l *Locale) SetSnippets(s Snippets) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.TextSnippets = s
+
+	l.tmplMu.Lock()
+	defer l.tmplMu.Unlock()
+
+	l.templates = make(map[string][]*Template, len(s))
+	for key, values := range s {
+		l.templates[key] = parseTemplates(values)
+	}
+}
+
+// template returns the parsed variation i of the key, translations set on TextSnippets are parsed on first use.
+func (l *Locale) template(key string, i int, text string) *Template {
+	l.tmplMu.Lock()
+	defer l.tmplMu.Unlock()
+
+	tmpls := l.templates[key]
+	if i < len(tmpls) && tmpls[i] != nil && tmpls[i].text == text {
+		return tmpls[i]
+	}
+
+	if l.templates == nil {
+		l.templates = map[string][]*Template{}
+	}
+
+	if i >= len(tmpls) {
+		tmpls = append(tmpls, make([]*Template, i+1-len(tmpls))...)
 	}
 
-	l.appendErrorMissingParam(key, []string{t})
+	tmpls[i], _ = ParseTemplate(text)
+	l.templates[key] = tmpls
+
+	return tmpls[i]
+}
+
+// values returns the translations of the key.
+func (l *Locale) values(key string) []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.TextSnippets[key]
+}
+
+// Get returns the first translation.
+func (l *Locale) Get(key string, args ...interface{}) string {
+	t, errs := l.get(key, args...)
+	l.appendErrors(errs)
 
 	return t
 }
 
 // GetAny returns a random translation.
 func (l *Locale) GetAny(key string, args ...interface{}) string {
-	t, err := l.TextSnippets.GetAny(key, args...)
-	if err != nil {
-		var locaErr NoTranslationError
-		switch {
-		case errors.As(err, &locaErr):
-			locaErr.Locale = l.GetName()
-			l.errors = append(l.errors, locaErr)
-		default:
-			l.errors = append(l.errors, err)
-		}
-	}
-
-	l.appendErrorMissingParam(key, []string{t})
+	t, errs := l.getAny(key, nil, args...)
+	l.appendErrors(errs)
 
 	return t
 }
 
 // GetAll returns all translations.
 func (l *Locale) GetAll(key string, args ...interface{}) []string {
-	t, err := l.TextSnippets.GetAll(key, args...)
-	if err != nil {
-		var locaerr NoTranslationError
-		switch {
-		case errors.As(err, &locaerr):
-			locaerr.Locale = l.GetName()
-			l.errors = append(l.errors, locaerr)
-		default:
-			l.errors = append(l.errors, err)
-		}
-	}
+	t, errs := l.getAll(key, args...)
+	l.appendErrors(errs)
+
+	return t
+}
 
-	l.appendErrorMissingParam(key, t)
+// GetPlural returns the first translation of the plural form of n, see PluralCategoryOf.
+//
+// The key is looked up with the category as postfix (e.g. "Points_one"), falling back to "_other".
+func (l *Locale) GetPlural(key string, n interface{}, args ...interface{}) string {
+	t, errs := l.getPlural(key, n, args...)
+	l.appendErrors(errs)
 
 	return t
 }
 
+// GetSnippets returns a copy of the translations.
+func (l *Locale) GetSnippets() Snippets {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	s := make(Snippets, len(l.TextSnippets))
+	for k, v := range l.TextSnippets {
+		s[k] = append([]string(nil), v...)
+	}
+
+	return s
+}
+
 // GetErrors returns key lookup errors that occurred.
 func (l *Locale) GetErrors() []error {
-	return l.errors
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append([]error(nil), l.errors...)
 }
 
 // ResetErrors resets existing errors.
 func (l *Locale) ResetErrors() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	l.errors = nil
 }
 
-func (l *Locale) appendErrorMissingParam(key string, texts []string) {
-	for _, t := range texts {
-		if strings.Contains(t, "%!") &&
-			strings.Contains(t, "(MISSING)") {
-			l.errors = append(l.errors, MissingPlaceholderError{l.GetName(), key, ""})
+// SetRandSource sets the source of randomness for GetAny, e.g. a seeded source in tests.
+func (l *Locale) SetRandSource(src rand.Source) {
+	l.rndMu.Lock()
+	defer l.rndMu.Unlock()
+
+	l.rnd = rand.New(src) //nolint:gosec
+}
+
+// Scope returns an instance sharing the translations of the locale, collecting its own errors.
+func (l *Locale) Scope() LocaleInstance {
+	return &scopedLocale{Locale: l, errs: &errorList{}}
+}
+
+// WithHistory returns an instance whose GetAny avoids the variation used last time, see History.
+// Errors are collected by the locale.
+func (l *Locale) WithHistory(h History) LocaleInstance {
+	return &scopedLocale{Locale: l, history: h}
+}
+
+func (l *Locale) get(key string, args ...interface{}) (string, []error) {
+	values := l.values(key)
+	if len(values) == 0 {
+		return "", l.lookupErrors(NoTranslationError{"", key, ""})
+	}
+
+	t, err := l.template(key, 0, values[0]).Execute(key, args...)
+
+	return t, l.lookupErrors(err)
+}
+
+func (l *Locale) getAny(key string, h History, args ...interface{}) (string, []error) {
+	values := l.values(key)
+	if len(values) == 0 {
+		return "", l.lookupErrors(NoTranslationError{"", key, ""})
+	}
+
+	i := l.pick(key, len(values), h)
+	t, err := l.template(key, i, values[i]).Execute(key, args...)
+
+	return t, l.lookupErrors(err)
+}
+
+// pick returns a random variation of the key, avoiding the variation used last time in the history.
+func (l *Locale) pick(key string, n int, h History) int {
+	// a single variation is not remembered, there is nothing to avoid
+	if h == nil || n <= 1 {
+		return l.intn(n)
+	}
+
+	last, ok := h.Last(key)
+
+	avoid := ok && last >= 0 && last < n
+	if avoid {
+		n--
+	}
+
+	i := l.intn(n)
+	if avoid && i >= last {
+		i++
+	}
+
+	h.Remember(key, i)
+
+	return i
+}
+
+func (l *Locale) intn(n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	l.rndMu.Lock()
+	defer l.rndMu.Unlock()
+
+	if l.rnd == nil {
+		return rand.Intn(n) //nolint:gosec
+	}
+
+	return l.rnd.Intn(n)
+}
+
+func (l *Locale) getAll(key string, args ...interface{}) ([]string, []error) {
+	values := l.values(key)
+	if len(values) == 0 {
+		return []string{}, l.lookupErrors(NoTranslationError{"", key, ""})
+	}
+
+	var errs []error
+
+	t := make([]string, len(values))
+	for i, v := range values {
+		var err error
+
+		t[i], err = l.template(key, i, v).Execute(key, args...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return t, l.lookupErrors(errors.Join(errs...))
+}
+
+func (l *Locale) getPlural(key string, n interface{}, args ...interface{}) (string, []error) {
+	op, err := NewPluralOperands(n)
+	if err != nil {
+		return "", []error{ValidationError{l.GetName(), key, err.Error()}}
+	}
+
+	keys := pluralKeys(key, op, PluralRuleFor(l.GetName())(op))
+	for _, k := range keys {
+		if l.has(k) {
+			return l.get(k, args...)
+		}
+	}
+
+	return l.get(keys[len(keys)-1], args...)
+}
+
+// has returns true if the locale has a translation for the key.
+func (l *Locale) has(key string) bool {
+	return len(l.values(key)) > 0
+}
+
+func (l *Locale) appendErrors(errs []error) {
+	if len(errs) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.errors = append(l.errors, errs...)
+}
+
+// lookupErrors returns the errors of a lookup with the locale name set.
+func (l *Locale) lookupErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, l.lookupErrors(e)...)
 		}
+
+		return errs
 	}
+
+	switch e := err.(type) {
+	case NoTranslationError:
+		e.Locale = l.GetName()
+		err = e
+	case MissingPlaceholderError:
+		e.Locale = l.GetName()
+		err = e
+	case UnusedArgumentError:
+		e.Locale = l.GetName()
+		err = e
+	case ValidationError:
+		e.Locale = l.GetName()
+		err = e
+	}
+
+	return append(errs, err)
+}
+
+// scopedLocale is a locale collecting its own errors or using a history.
+//
+// Without its own errors, errors are collected by the locale.
+type scopedLocale struct {
+	*Locale
+
+	errs    *errorList
+	history History
+}
+
+// errorList is a list of errors safe for concurrent use.
+type errorList struct {
+	mu     sync.Mutex
+	errors []error
+}
+
+// Get returns the first translation.
+func (s *scopedLocale) Get(key string, args ...interface{}) string {
+	t, errs := s.get(key, args...)
+	s.appendErrors(errs)
+
+	return t
+}
+
+// GetAny returns a random translation, avoiding the last variation if a history is used.
+func (s *scopedLocale) GetAny(key string, args ...interface{}) string {
+	t, errs := s.getAny(key, s.history, args...)
+	s.appendErrors(errs)
+
+	return t
+}
+
+// GetAll returns all translations.
+func (s *scopedLocale) GetAll(key string, args ...interface{}) []string {
+	t, errs := s.getAll(key, args...)
+	s.appendErrors(errs)
+
+	return t
+}
+
+// GetPlural returns the first translation of the plural form of n.
+func (s *scopedLocale) GetPlural(key string, n interface{}, args ...interface{}) string {
+	t, errs := s.getPlural(key, n, args...)
+	s.appendErrors(errs)
+
+	return t
+}
+
+// GetErrors returns key lookup errors that occurred in this scope.
+func (s *scopedLocale) GetErrors() []error {
+	if s.errs == nil {
+		return s.Locale.GetErrors()
+	}
+
+	s.errs.mu.Lock()
+	defer s.errs.mu.Unlock()
+
+	return append([]error(nil), s.errs.errors...)
+}
+
+// ResetErrors resets existing errors of this scope.
+func (s *scopedLocale) ResetErrors() {
+	if s.errs == nil {
+		s.Locale.ResetErrors()
+		return
+	}
+
+	s.errs.mu.Lock()
+	defer s.errs.mu.Unlock()
+
+	s.errs.errors = nil
+}
+
+// Scope returns a new scope of the underlying locale, using the same history.
+func (s *scopedLocale) Scope() LocaleInstance {
+	return &scopedLocale{Locale: s.Locale, errs: &errorList{}, history: s.history}
+}
+
+// WithHistory returns an instance using the history, sharing the errors of this scope.
+func (s *scopedLocale) WithHistory(h History) LocaleInstance {
+	return &scopedLocale{Locale: s.Locale, errs: s.errs, history: h}
+}
+
+func (s *scopedLocale) appendErrors(errs []error) {
+	if s.errs == nil {
+		s.Locale.appendErrors(errs)
+		return
+	}
+
+	if len(errs) == 0 {
+		return
+	}
+
+	s.errs.mu.Lock()
+	defer s.errs.mu.Unlock()
+
+	s.errs.errors = append(s.errs.errors, errs...)
 }
 
 // Snippets is the actual representation of key -> array of translations in a locale.
@@ -381,7 +861,7 @@ func (s Snippets) GetFirst(key string, args ...interface{}) (string, error) {
 		return "", NoTranslationError{"", key, ""}
 	}
 
-	return fmt.Sprintf(s[key][0], args...), nil
+	return format(key, s[key][0], args)
 }
 
 // GetAny returns a random translation for the snippet.
@@ -392,13 +872,13 @@ func (s Snippets) GetAny(key string, args ...interface{}) (string, error) {
 	}
 
 	if len(s[key]) == 1 {
-		return fmt.Sprintf(s[key][0], args...), nil
+		return format(key, s[key][0], args)
 	}
 
 	l := len(s[key])
 	r := rand.Intn(l) //nolint:gosec
 
-	return fmt.Sprintf(s[key][r], args...), nil
+	return format(key, s[key][r], args)
 }
 
 // GetAll returns all translations of the snippet.
@@ -408,10 +888,40 @@ func (s Snippets) GetAll(key string, args ...interface{}) ([]string, error) {
 		return []string{}, NoTranslationError{"", key, ""}
 	}
 
+	var errs []error
+
 	r := make([]string, len(s[key]))
 	for i, v := range s[key] {
-		r[i] = fmt.Sprintf(v, args...)
+		t, err := format(key, v, args)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		r[i] = t
+	}
+
+	return r, errors.Join(errs...)
+}
+
+// format executes the translation as template, see Template.Execute.
+//
+// An invalid named placeholder is only an error with named arguments, with positional arguments the text is
+// formatted with fmt. The loader and Verify report invalid placeholders as ValidationError.
+func format(key, text string, args []interface{}) (string, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil && isNamed(args) {
+		return text, ValidationError{"", key, err.Error()}
 	}
 
-	return r, nil
+	return tmpl.Execute(key, args...)
+}
+
+func isNamed(args []interface{}) bool {
+	if len(args) != 1 {
+		return false
+	}
+
+	_, ok := args[0].(Args)
+
+	return ok
 }
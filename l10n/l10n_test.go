@@ -1,8 +1,10 @@
 package l10n_test
 
 import (
+	"fmt"
 	"github.com/drpsychick/go-alexa-lambda/l10n"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 )
 
@@ -178,6 +180,22 @@ func TestLocale_Set(t *testing.T) {
 	assert.Equal(t, vals, v2)
 }
 
+// Locale templates are replaced with the translations.
+func TestLocale_SetReplacesTemplates(t *testing.T) {
+	l := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{"Hi": {"Hello {name}"}}}
+	assert.Equal(t, "Hello Bob", l.Get("Hi", l10n.Args{"name": "Bob"}))
+
+	l.Set("Hi", []string{"Hi {name}!"})
+	assert.Equal(t, "Hi Bob!", l.Get("Hi", l10n.Args{"name": "Bob"}))
+
+	l.SetSnippets(l10n.Snippets{"Hi": {"Hey {name}", "Hey {name}"}})
+	assert.Equal(t, []string{"Hey Bob", "Hey Bob"}, l.GetAll("Hi", l10n.Args{"name": "Bob"}))
+
+	l.TextSnippets["Hi"] = []string{"Yo %s"}
+	assert.Equal(t, "Yo Bob", l.Get("Hi", "Bob"))
+	assert.Empty(t, l.GetErrors())
+}
+
 // Locale get random key is covered.
 func TestLocale_GetAny(t *testing.T) {
 	// requires registry setup
@@ -238,5 +256,194 @@ func TestLocale_ErrorNoParam(t *testing.T) {
 	assert.Equal(t, "Hello %!s(MISSING)", l.Get(WithParam))
 	assert.Len(t, l.GetErrors(), 1)
 	assert.NotEmpty(t, l.GetErrors())
-	assert.Equal(t, "locale de-DE: key '"+WithParam+"' is missing a placeholder in translation", l.GetErrors()[0].Error())
+	assert.Equal(t, "locale de-DE: key '"+WithParam+"' is missing placeholder '%s' in translation", l.GetErrors()[0].Error())
+}
+
+// Locale scope collects its own errors.
+func TestLocale_Scope(t *testing.T) {
+	l := l10n.NewLocale("de-DE")
+	l.Set(Greeting, []string{"Hallo"})
+
+	s := l10n.Scope(l)
+	assert.Equal(t, "de-DE", s.GetName())
+	assert.Equal(t, "Hallo", s.Get(Greeting))
+	assert.Empty(t, s.Get("not exists"))
+	assert.Empty(t, s.GetAny("not exists"))
+	assert.Empty(t, s.GetAll("not exists"))
+	assert.Len(t, s.GetErrors(), 3)
+	assert.Empty(t, l.GetErrors())
+
+	s2 := l10n.Scope(s)
+	assert.Empty(t, s2.GetErrors())
+
+	s.ResetErrors()
+	assert.Empty(t, s.GetErrors())
+}
+
+// Registry and locale are safe for concurrent use.
+func TestRegistry_Concurrent(t *testing.T) {
+	r := l10n.NewRegistry()
+	l := l10n.NewLocale("de-DE")
+	assert.NoError(t, r.Register(l))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = r.Register(l10n.NewLocale(fmt.Sprintf("xx-%d", i)))
+			loc, err := r.Resolve("de-DE")
+			assert.NoError(t, err)
+			loc.Set(Greeting, []string{"Hallo"})
+			s := l10n.Scope(loc)
+			s.Get("not exists")
+			assert.Len(t, s.GetErrors(), 1)
+			loc.GetAny(Greeting)
+			_ = r.GetLocales()
+			_ = r.GetDefault()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, r.GetLocales(), 11)
+}
+
+// Registry resolve with fallback locales is covered.
+func TestRegistry_ResolveFallback(t *testing.T) {
+	r := l10n.NewRegistry()
+	us := l10n.NewLocale("en-US")
+	gb := l10n.NewLocale("en-GB")
+	assert.NoError(t, r.Register(us))
+	assert.NoError(t, r.Register(gb, l10n.FallbackFor("en")))
+
+	l, err := r.Resolve("en-AU")
+	assert.NoError(t, err)
+	assert.Equal(t, gb, l)
+
+	l, err = r.Resolve("en-US")
+	assert.NoError(t, err)
+	assert.Equal(t, us, l)
+
+	_, err = r.Resolve("de-AT")
+	assert.Error(t, err)
+
+	// locale specific fallback
+	assert.NoError(t, r.Register(l10n.NewLocale("en-CA"), l10n.FallbackFor("en-IN")))
+	l, err = r.Resolve("en-IN")
+	assert.NoError(t, err)
+	assert.Equal(t, "en-CA", l.GetName())
+
+	// fails: only one fallback per language
+	err = r.Register(l10n.NewLocale("en-NZ"), l10n.FallbackFor("en"))
+	assert.Error(t, err)
+}
+
+// Locale key lookup in fallback locale is covered.
+func TestLocale_KeyFallback(t *testing.T) {
+	r := l10n.NewRegistry().(*l10n.Registry)
+	at := &l10n.Locale{Name: "de-AT", TextSnippets: l10n.Snippets{Greeting: {"Servus"}}}
+	de := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{Greeting: {"Hallo"}, WithParam: {"Hallo %s"}}}
+	assert.NoError(t, r.Register(at))
+	assert.NoError(t, r.Register(de, l10n.FallbackFor("de")))
+
+	s := r.Scope(at)
+	assert.Equal(t, "Servus", s.Get(Greeting))
+	assert.Equal(t, "Hallo Welt", s.Get(WithParam, "Welt"))
+	assert.Equal(t, "Hallo Welt", s.GetAny(WithParam, "Welt"))
+	assert.Equal(t, []string{"Hallo Welt"}, s.GetAll(WithParam, "Welt"))
+	assert.Empty(t, s.GetErrors())
+
+	assert.Empty(t, s.Get("not exists"))
+	assert.Len(t, s.GetErrors(), 1)
+	assert.Equal(t, "locale de-AT: no translation for key 'not exists'", s.GetErrors()[0].Error())
+	assert.Empty(t, at.GetErrors())
+
+	// the registered locale only uses its own keys
+	assert.Empty(t, at.Get(WithParam, "Welt"))
+
+	assert.Equal(t, "Hallo", r.Scope(de).Get(Greeting))
+	assert.Equal(t, "Hallo", l10n.ScopeIn(r, de).Get(Greeting))
+}
+
+// Locale key lookup along a chain of fallbacks to the default locale is covered.
+func TestLocale_KeyFallbackChain(t *testing.T) {
+	r := l10n.NewRegistry().(*l10n.Registry)
+	us := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{"Bye": {"Bye"}, "Only": {"English"}}}
+	de := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{Greeting: {"Hallo"}, "Bye": {"Tschüss"}}}
+	at := &l10n.Locale{Name: "de-AT", TextSnippets: l10n.Snippets{Greeting: {"Servus"}}}
+	ch := &l10n.Locale{Name: "de-CH", TextSnippets: l10n.Snippets{"Own": {"Grüezi"}}}
+	fr := &l10n.Locale{Name: "fr-FR", TextSnippets: l10n.Snippets{}}
+	assert.NoError(t, r.Register(us, l10n.AsDefault()))
+	assert.NoError(t, r.Register(de, l10n.FallbackFor("de")))
+	assert.NoError(t, r.Register(at, l10n.FallbackFor("de-CH")))
+	assert.NoError(t, r.Register(ch))
+	assert.NoError(t, r.Register(fr))
+
+	s := r.Scope(ch)
+	assert.Equal(t, "Grüezi", s.Get("Own"))
+	assert.Equal(t, "Servus", s.Get(Greeting))
+	assert.Equal(t, "Tschüss", s.Get("Bye"))
+	assert.Equal(t, "English", s.Get("Only"))
+	assert.Empty(t, s.GetErrors())
+
+	// fallbacks fall back to the default locale like the locales behind them
+	assert.Equal(t, "English", r.Scope(de).Get("Only"))
+	assert.Equal(t, "English", r.Scope(at).Get("Only"))
+
+	// locales without fallback only use their own keys
+	fs := r.Scope(fr)
+	assert.Empty(t, fs.Get("Bye"))
+	assert.Len(t, fs.GetErrors(), 1)
+}
+
+// Locale key lookup with cyclic fallbacks is covered.
+func TestLocale_KeyFallbackCycle(t *testing.T) {
+	r := l10n.NewRegistry().(*l10n.Registry)
+	a := &l10n.Locale{Name: "xx-A", TextSnippets: l10n.Snippets{"A": {"a"}}}
+	b := &l10n.Locale{Name: "xx-B", TextSnippets: l10n.Snippets{"B": {"b"}}}
+	assert.NoError(t, r.Register(a, l10n.FallbackFor("xx-B")))
+	assert.NoError(t, r.Register(b, l10n.FallbackFor("xx-A")))
+
+	s := r.Scope(a)
+	assert.Equal(t, "b", s.Get("B"))
+	assert.Equal(t, "a", r.Scope(b).Get("A"))
+	assert.Empty(t, s.Get("C"))
+	assert.Len(t, s.GetErrors(), 1)
+}
+
+// Locale registered in two registries uses the fallbacks of the registry it is scoped in.
+func TestLocale_KeyFallbackRegistries(t *testing.T) {
+	de := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{"Bye": {"Tschüss"}}}
+	at := &l10n.Locale{Name: "de-AT", TextSnippets: l10n.Snippets{}}
+	de2 := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{"Bye": {"Servus"}}}
+
+	r1 := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r1.Register(de, l10n.FallbackFor("de")))
+	assert.NoError(t, r1.Register(at))
+
+	r2 := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r2.Register(de2, l10n.FallbackFor("de")))
+	assert.NoError(t, r2.Register(at))
+
+	assert.Equal(t, "Tschüss", r1.Scope(at).Get("Bye"))
+	assert.Equal(t, "Servus", r2.Scope(at).Get("Bye"))
+}
+
+// customLocale is a LocaleInstance that is not a *Locale.
+type customLocale struct {
+	*l10n.Locale
+}
+
+// Locale instances of other types look up keys in their fallbacks.
+func TestLocale_KeyFallbackCustomLocale(t *testing.T) {
+	de := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{"Bye": {"Tschüss"}}}
+	at := customLocale{&l10n.Locale{Name: "de-AT", TextSnippets: l10n.Snippets{Greeting: {"Servus"}}}}
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(de, l10n.FallbackFor("de")))
+	assert.NoError(t, r.Register(at))
+
+	s := r.Scope(at)
+	assert.Equal(t, "Servus", s.Get(Greeting))
+	assert.Equal(t, "Tschüss", s.Get("Bye"))
 }
@@ -0,0 +1,521 @@
+package l10n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the file format of translations.
+type Format string
+
+// Supported translation file formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatPO   Format = "po"
+)
+
+// FormatOf returns the format of the file by its extension.
+func FormatOf(file string) (Format, error) {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".po":
+		return FormatPO, nil
+	default:
+		return "", fmt.Errorf("unsupported translation file '%s'", file)
+	}
+}
+
+// ValidationError defines an invalid translation.
+type ValidationError struct {
+	Locale string
+	Key    string
+	Reason string
+}
+
+// Error returns a string of the error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("locale %s: key '%s': %s", e.Locale, e.Key, e.Reason)
+}
+
+// GetLocale returns the locale of the error.
+func (e ValidationError) GetLocale() string {
+	return e.Locale
+}
+
+// GetKey returns the associated key.
+func (e ValidationError) GetKey() string {
+	return e.Key
+}
+
+// GetPlaceholder returns the placeholder concerned.
+func (e ValidationError) GetPlaceholder() string {
+	return ""
+}
+
+// LoadFS loads all translation files in the directory of the file system, one file per locale.
+//
+// The locale name is the file name without extension, e.g. "de-DE.yaml".
+// Files with unsupported extensions are ignored.
+func LoadFS(fsys fs.FS, dir string) ([]*Locale, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var locales []*Locale
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if _, err := FormatOf(e.Name()); err != nil {
+			continue
+		}
+
+		loc, err := LoadFileFS(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		locales = append(locales, loc)
+	}
+
+	return locales, nil
+}
+
+// LoadDir loads all translation files in the directory, see LoadFS.
+func LoadDir(dir string) ([]*Locale, error) {
+	return LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFileFS loads a translation file from the file system.
+func LoadFileFS(fsys fs.FS, file string) (*Locale, error) {
+	format, err := FormatOf(file)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fsys.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+
+	loc, err := Decode(f, name, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return loc, nil
+}
+
+// LoadFile loads a translation file.
+func LoadFile(file string) (*Locale, error) {
+	return LoadFileFS(os.DirFS(filepath.Dir(file)), filepath.Base(file))
+}
+
+// RegisterFS loads all translation files of the directory and registers them.
+func RegisterFS(registry LocaleRegistry, fsys fs.FS, dir string) error {
+	locales, err := LoadFS(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, loc := range locales {
+		if err := registry.Register(loc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReloadFS loads all translation files of the directory again, replacing the translations of registered locales
+// and registering new locales.
+//
+// Locales not loaded from files (e.g. not of type *Locale) are not replaced and fail as already registered.
+func ReloadFS(registry LocaleRegistry, fsys fs.FS, dir string) error {
+	locales, err := LoadFS(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	registered := registry.GetLocales()
+
+	for _, loc := range locales {
+		if l, ok := registered[loc.Name].(*Locale); ok {
+			l.SetSnippets(loc.GetSnippets())
+			continue
+		}
+
+		if err := registry.Register(loc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode reads the translations of the named locale in the given format.
+//
+// Duplicate keys and empty values are returned as ValidationError.
+func Decode(r io.Reader, name string, format Format) (*Locale, error) {
+	var (
+		entries []entry
+		err     error
+	)
+
+	switch format {
+	case FormatJSON:
+		entries, err = decodeJSON(r)
+	case FormatYAML:
+		entries, err = decodeYAML(r)
+	case FormatPO:
+		entries, err = decodePO(r)
+	default:
+		err = fmt.Errorf("unsupported format '%s'", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	snippets := Snippets{}
+
+	var errs []error
+
+	for _, e := range entries {
+		if _, ok := snippets[e.key]; ok && !e.variation {
+			errs = append(errs, ValidationError{name, e.key, "duplicate key"})
+			continue
+		}
+
+		if len(e.values) == 0 {
+			errs = append(errs, ValidationError{name, e.key, "no value"})
+			continue
+		}
+
+		for _, v := range e.values {
+			if strings.TrimSpace(v) == "" {
+				errs = append(errs, ValidationError{name, e.key, "empty value"})
+				break
+			}
+
+			if _, err := ParseTemplate(v); err != nil {
+				errs = append(errs, ValidationError{name, e.key, err.Error()})
+				break
+			}
+		}
+
+		snippets[e.key] = append(snippets[e.key], e.values...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	loc := NewLocale(name)
+	loc.SetSnippets(snippets)
+
+	return loc, nil
+}
+
+// Encode writes the translations of the locale in the given format, sorted by key.
+func Encode(w io.Writer, loc *Locale, format Format) error {
+	snippets := loc.GetSnippets()
+
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(exportValues(snippets))
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(exportValues(snippets)); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	case FormatPO:
+		return encodePO(w, loc.GetName(), snippets)
+	default:
+		return fmt.Errorf("unsupported format '%s'", format)
+	}
+}
+
+// Export writes one file per locale of the registry into the directory.
+func Export(registry LocaleRegistry, dir string, format Format) error {
+	for name, l := range registry.GetLocales() {
+		loc, ok := l.(*Locale)
+		if !ok {
+			return fmt.Errorf("locale %s: cannot export %T", name, l)
+		}
+
+		var buf bytes.Buffer
+		if err := Encode(&buf, loc, format); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, name+"."+string(format)), buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entry is a decoded key with its translations.
+type entry struct {
+	key       string
+	values    []string
+	variation bool
+}
+
+// exportValues returns single translations as string, multiple as list.
+func exportValues(s Snippets) map[string]interface{} {
+	m := make(map[string]interface{}, len(s))
+	for k, v := range s {
+		if len(v) == 1 {
+			m[k] = v[0]
+			continue
+		}
+
+		m[k] = v
+	}
+
+	return m
+}
+
+func decodeJSON(r io.Reader) ([]entry, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, errors.New("translations must be an object")
+	}
+
+	var entries []entry
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := tok.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		values, err := jsonValues(raw)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %w", key, err)
+		}
+
+		entries = append(entries, entry{key: key, values: values})
+	}
+
+	return entries, nil
+}
+
+func jsonValues(raw json.RawMessage) ([]string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(raw, &l); err != nil {
+		return nil, errors.New("value must be a string or a list of strings")
+	}
+
+	return l, nil
+}
+
+func decodeYAML(r io.Reader) ([]entry, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("translations must be a mapping")
+	}
+
+	m := doc.Content[0]
+	entries := make([]entry, 0, len(m.Content)/2)
+
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, val := m.Content[i].Value, m.Content[i+1]
+
+		var values []string
+
+		switch val.Kind {
+		case yaml.ScalarNode:
+			values = []string{val.Value}
+		case yaml.SequenceNode:
+			if err := val.Decode(&values); err != nil {
+				return nil, fmt.Errorf("line %d: key '%s': %w", val.Line, key, err)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: key '%s': value must be a string or a list of strings", val.Line, key)
+		}
+
+		entries = append(entries, entry{key: key, values: values})
+	}
+
+	return entries, nil
+}
+
+// poEntry is an entry of a PO file.
+type poEntry struct {
+	ctxt, id, str string
+	hasID         bool
+}
+
+// decodePO reads a gettext PO file, msgid is the key and msgstr the translation.
+//
+// Variations of a key are entries with the same msgid and a different msgctxt.
+func decodePO(r io.Reader) ([]entry, error) {
+	var (
+		entries []entry
+		cur     *poEntry
+		field   *string
+		seen    = map[string]bool{}
+	)
+
+	flush := func() {
+		if cur != nil && cur.id != "" {
+			variation := seen[cur.id] && !seen[cur.id+"\x04"+cur.ctxt]
+			seen[cur.id], seen[cur.id+"\x04"+cur.ctxt] = true, true
+
+			entries = append(entries, entry{key: cur.id, values: []string{cur.str}, variation: variation})
+		}
+
+		cur, field = nil, nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		l := strings.TrimSpace(sc.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		kw, val := "", l
+		if !strings.HasPrefix(l, `"`) {
+			kw, val, _ = strings.Cut(l, " ")
+		}
+
+		str, err := strconv.Unquote(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch kw {
+		case "":
+			if field == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", line)
+			}
+
+			*field += str
+
+			continue
+		case "msgctxt", "msgid":
+			if cur != nil && (cur.hasID || kw == "msgctxt") {
+				flush()
+			}
+		case "msgstr":
+			if cur == nil || !cur.hasID {
+				return nil, fmt.Errorf("line %d: msgstr without msgid", line)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unsupported keyword '%s'", line, kw)
+		}
+
+		if cur == nil {
+			cur = &poEntry{}
+		}
+
+		switch kw {
+		case "msgctxt":
+			field = &cur.ctxt
+		case "msgid":
+			field, cur.hasID = &cur.id, true
+		default:
+			field = &cur.str
+		}
+
+		*field = str
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return entries, nil
+}
+
+func encodePO(w io.Writer, name string, s Snippets) error {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	bw := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(bw, "msgid \"\"\nmsgstr \"\"\n%s\n", strconv.Quote("Language: "+name+"\n"))
+
+	for _, k := range keys {
+		for i, v := range s[k] {
+			_, _ = bw.WriteString("\n")
+
+			if len(s[k]) > 1 {
+				_, _ = fmt.Fprintf(bw, "msgctxt %s\n", strconv.Quote(strconv.Itoa(i+1)))
+			}
+
+			_, _ = fmt.Fprintf(bw, "msgid %s\nmsgstr %s\n", strconv.Quote(k), strconv.Quote(v))
+		}
+	}
+
+	return bw.Flush()
+}
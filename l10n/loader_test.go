@@ -0,0 +1,176 @@
+package l10n_test
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+//go:embed testdata/locales
+var translations embed.FS
+
+func TestLoadFS(t *testing.T) {
+	locs, err := l10n.LoadFS(translations, "testdata/locales")
+	assert.NoError(t, err)
+	assert.Len(t, locs, 3)
+
+	want := map[string]l10n.Snippets{
+		"en-US": {Greeting: {"Hi", "Hello"}, WithParam: {"Hello %s"}},
+		"de-DE": {Greeting: {"Hi", "Hallo"}, WithParam: {"Hallo %s"}},
+		"fr-FR": {Greeting: {"Salut", "Bonjour"}, WithParam: {"Bonjour %s"}},
+	}
+	for _, l := range locs {
+		assert.Equal(t, want[l.GetName()], l.TextSnippets, l.GetName())
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	locs, err := l10n.LoadDir("testdata/locales")
+	assert.NoError(t, err)
+	assert.Len(t, locs, 3)
+
+	_, err = l10n.LoadDir("testdata/not-exists")
+	assert.Error(t, err)
+}
+
+func TestLoadFile(t *testing.T) {
+	l, err := l10n.LoadFile("testdata/locales/de-DE.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "de-DE", l.GetName())
+	assert.Equal(t, "Hallo Welt", l.Get(WithParam, "Welt"))
+
+	_, err = l10n.LoadFile("testdata/locales/README.txt")
+	assert.Error(t, err)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		file string
+		keys []string
+	}{
+		{"en-US.json", []string{"greeting", "empty", "none", "broken"}},
+		{"de-DE.yaml", []string{"greeting"}},
+		{"fr-FR.po", []string{"greeting"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			_, err := l10n.LoadFile(filepath.Join("testdata/invalid", tt.file))
+			assert.Error(t, err)
+
+			var keys []string
+			for _, e := range errors.Unwrap(err).(interface{ Unwrap() []error }).Unwrap() {
+				var vErr l10n.ValidationError
+				if errors.As(e, &vErr) {
+					keys = append(keys, vErr.GetKey())
+				}
+			}
+			assert.Equal(t, tt.keys, keys)
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		format l10n.Format
+	}{
+		{"JSONArray", `[]`, l10n.FormatJSON},
+		{"JSONObjectValue", `{"key": {"foo": "bar"}}`, l10n.FormatJSON},
+		{"JSONInvalid", `{"key": `, l10n.FormatJSON},
+		{"YAMLList", `- foo`, l10n.FormatYAML},
+		{"YAMLMappingValue", "key:\n  foo: bar", l10n.FormatYAML},
+		{"POUnexpectedString", `"foo"`, l10n.FormatPO},
+		{"POKeyword", `msgid_plural "foo"`, l10n.FormatPO},
+		{"POQuote", `msgid foo`, l10n.FormatPO},
+		{"POMsgstr", `msgstr "foo"`, l10n.FormatPO},
+		{"Format", ``, l10n.Format("xml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := l10n.Decode(bytes.NewBufferString(tt.in), "en-US", tt.format)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecode_LiteralBrace(t *testing.T) {
+	in := `{"Smile": "Smile :-{ %s", "JSON": "Send {\"a\": %d}", "Named": "Hi {name}, { }"}`
+
+	l, err := l10n.Decode(bytes.NewBufferString(in), "en-US", l10n.FormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, "Smile :-{ Joe", l.Get("Smile", "Joe"))
+	assert.Equal(t, `Send {"a": 1}`, l.Get("JSON", 1))
+	assert.Equal(t, "Hi Joe, { }", l.Get("Named", l10n.Args{"name": "Joe"}))
+	assert.Empty(t, l.GetErrors())
+
+	_, err = l10n.Decode(bytes.NewBufferString(`{"Invalid": "Hi {1st}"}`), "en-US", l10n.FormatJSON)
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	l := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		Greeting:  {"Hi", "Hello"},
+		WithParam: {"Hello \"%s\" <3"},
+	}}
+
+	for _, f := range []l10n.Format{l10n.FormatJSON, l10n.FormatYAML, l10n.FormatPO} {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := l10n.Encode(&buf, l, f)
+			assert.NoError(t, err)
+
+			l2, err := l10n.Decode(&buf, "en-US", f)
+			assert.NoError(t, err)
+			assert.Equal(t, l.TextSnippets, l2.TextSnippets)
+		})
+	}
+
+	err := l10n.Encode(&bytes.Buffer{}, l, l10n.Format("xml"))
+	assert.Error(t, err)
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	r := l10n.NewRegistry()
+	err := l10n.RegisterFS(r, translations, "testdata/locales")
+	assert.NoError(t, err)
+
+	err = l10n.Export(r, dir, l10n.FormatYAML)
+	assert.NoError(t, err)
+
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 3)
+
+	locs, err := l10n.LoadDir(dir)
+	assert.NoError(t, err)
+	for _, l := range locs {
+		orig, _ := r.Resolve(l.GetName())
+		assert.Equal(t, orig.(*l10n.Locale).TextSnippets, l.TextSnippets)
+	}
+}
+
+func TestReloadFS(t *testing.T) {
+	fsys := fstest.MapFS{"en-US.json": {Data: []byte(`{"Hello": "Hello"}`)}}
+	r := l10n.NewRegistry()
+	assert.NoError(t, l10n.RegisterFS(r, fsys, "."))
+
+	loc, _ := r.Resolve("en-US")
+
+	fsys["en-US.json"] = &fstest.MapFile{Data: []byte(`{"Hello": "Hi"}`)}
+	fsys["de-DE.json"] = &fstest.MapFile{Data: []byte(`{"Hello": "Hallo"}`)}
+	assert.NoError(t, l10n.ReloadFS(r, fsys, "."))
+
+	assert.Equal(t, "Hi", loc.Get("Hello"))
+	assert.Len(t, r.GetLocales(), 2)
+
+	fsys["en-US.json"] = &fstest.MapFile{Data: []byte(`{"Hello": ""}`)}
+	assert.Error(t, l10n.ReloadFS(r, fsys, "."))
+	assert.Equal(t, "Hi", loc.Get("Hello"))
+}
@@ -0,0 +1,247 @@
+package l10n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PluralCategory is a CLDR plural category.
+type PluralCategory string
+
+// CLDR plural categories.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// PluralOperands are the CLDR operands of a number.
+type PluralOperands struct {
+	// N is the absolute value.
+	N float64
+	// I is the integer digits.
+	I int64
+	// V is the number of visible fraction digits.
+	V int
+	// F is the visible fraction digits.
+	F int64
+}
+
+// NewPluralOperands returns the operands of an integer, a float or a number as string (e.g. "1.50").
+func NewPluralOperands(n interface{}) (PluralOperands, error) {
+	var s string
+
+	switch v := n.(type) {
+	case int:
+		s = strconv.FormatInt(int64(v), 10)
+	case int8:
+		s = strconv.FormatInt(int64(v), 10)
+	case int16:
+		s = strconv.FormatInt(int64(v), 10)
+	case int32:
+		s = strconv.FormatInt(int64(v), 10)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case uint:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		s = v
+	default:
+		return PluralOperands{}, fmt.Errorf("invalid plural count type %T", n)
+	}
+
+	s = strings.TrimPrefix(s, "-")
+
+	num, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(num, 0) || math.IsNaN(num) {
+		return PluralOperands{}, fmt.Errorf("invalid plural count '%v'", n)
+	}
+
+	op := PluralOperands{N: num}
+
+	intPart, frac, _ := strings.Cut(s, ".")
+	op.I, _ = strconv.ParseInt(intPart, 10, 64)
+
+	if frac != "" {
+		op.V = len(frac)
+		op.F, _ = strconv.ParseInt(frac, 10, 64)
+	}
+
+	return op, nil
+}
+
+// PluralRule returns the plural category of the operands.
+type PluralRule func(op PluralOperands) PluralCategory
+
+var (
+	pluralMu    sync.RWMutex
+	pluralRules = map[string]PluralRule{
+		"de": pluralOneInteger,
+		"en": pluralOneInteger,
+		"es": pluralSpanish,
+		"fr": pluralFrench,
+		"it": pluralItalian,
+		"ja": pluralOtherOnly,
+		"pt": pluralPortuguese,
+		"hi": pluralHindi,
+	}
+)
+
+// RegisterPluralRule sets the plural rule of a language (e.g. "de") or a specific locale (e.g. "de-CH").
+func RegisterPluralRule(locale string, rule PluralRule) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+
+	pluralRules[locale] = rule
+}
+
+// PluralRuleFor returns the plural rule of the locale, falling back to its language.
+//
+// Locales of unknown languages only use PluralOther.
+func PluralRuleFor(locale string) PluralRule {
+	pluralMu.RLock()
+	defer pluralMu.RUnlock()
+
+	if r, ok := pluralRules[locale]; ok {
+		return r
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+	if r, ok := pluralRules[lang]; ok {
+		return r
+	}
+
+	return pluralOtherOnly
+}
+
+// PluralCategoryOf returns the plural category of n in the locale.
+func PluralCategoryOf(locale string, n interface{}) (PluralCategory, error) {
+	op, err := NewPluralOperands(n)
+	if err != nil {
+		return PluralOther, err
+	}
+
+	return PluralRuleFor(locale)(op), nil
+}
+
+// Pluralizer is implemented by locales that translate plural forms, see Locale.GetPlural.
+type Pluralizer interface {
+	GetPlural(key string, n interface{}, args ...interface{}) string
+}
+
+// Plural returns the translation of the plural form of n, see Locale.GetPlural.
+//
+// If the locale does not implement Pluralizer, the "_other" translation of the key is returned.
+func Plural(loc LocaleInstance, key string, n interface{}, args ...interface{}) string {
+	if p, ok := loc.(Pluralizer); ok {
+		return p.GetPlural(key, n, args...)
+	}
+
+	return loc.Get(key+"_"+string(PluralOther), args...)
+}
+
+// pluralKeys returns the keys to look up for the plural of n, most specific first.
+//
+// An explicit "_zero" translation is used for 0 in every language.
+func pluralKeys(key string, op PluralOperands, cat PluralCategory) []string {
+	var keys []string
+	if op.N == 0 && cat != PluralZero {
+		keys = append(keys, key+"_"+string(PluralZero))
+	}
+
+	keys = append(keys, key+"_"+string(cat))
+	if cat != PluralOther {
+		keys = append(keys, key+"_"+string(PluralOther))
+	}
+
+	return keys
+}
+
+// pluralOtherOnly is the rule of languages without plural forms, e.g. Japanese.
+func pluralOtherOnly(PluralOperands) PluralCategory {
+	return PluralOther
+}
+
+// pluralOneInteger is the rule of English and German: one is "1" without fraction digits.
+func pluralOneInteger(op PluralOperands) PluralCategory {
+	if op.I == 1 && op.V == 0 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// isMillions is the "many" rule of French, Italian, Spanish and Portuguese for integers like 1000000.
+func isMillions(op PluralOperands) bool {
+	return op.I != 0 && op.I%1000000 == 0 && op.V == 0
+}
+
+func pluralFrench(op PluralOperands) PluralCategory {
+	switch {
+	case op.I == 0 || op.I == 1:
+		return PluralOne
+	case isMillions(op):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralItalian(op PluralOperands) PluralCategory {
+	switch {
+	case op.I == 1 && op.V == 0:
+		return PluralOne
+	case isMillions(op):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralSpanish(op PluralOperands) PluralCategory {
+	switch {
+	case op.N == 1:
+		return PluralOne
+	case isMillions(op):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralPortuguese(op PluralOperands) PluralCategory {
+	switch {
+	case op.I == 0 || op.I == 1:
+		return PluralOne
+	case isMillions(op):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralHindi(op PluralOperands) PluralCategory {
+	if op.I == 0 || op.N == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
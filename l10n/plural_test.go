@@ -0,0 +1,97 @@
+package l10n_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPluralCategoryOf(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      interface{}
+		want   l10n.PluralCategory
+	}{
+		{"en-US", 1, l10n.PluralOne},
+		{"en-US", 0, l10n.PluralOther},
+		{"en-US", 2, l10n.PluralOther},
+		{"en-GB", "1.0", l10n.PluralOther},
+		{"de-DE", 1, l10n.PluralOne},
+		{"de-DE", 1.5, l10n.PluralOther},
+		{"fr-FR", 0, l10n.PluralOne},
+		{"fr-CA", 1.5, l10n.PluralOne},
+		{"fr-FR", 2, l10n.PluralOther},
+		{"fr-FR", 1000000, l10n.PluralMany},
+		{"it-IT", 1, l10n.PluralOne},
+		{"it-IT", 2000000, l10n.PluralMany},
+		{"es-ES", 1, l10n.PluralOne},
+		{"es-MX", "1.0", l10n.PluralOne},
+		{"es-ES", 5, l10n.PluralOther},
+		{"ja-JP", 1, l10n.PluralOther},
+		{"en-IN", -1, l10n.PluralOne},
+		{"xx-XX", 1, l10n.PluralOther},
+	}
+
+	for _, tt := range tests {
+		got, err := l10n.PluralCategoryOf(tt.locale, tt.n)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s %v", tt.locale, tt.n)
+	}
+
+	_, err := l10n.PluralCategoryOf("en-US", "many")
+	assert.Error(t, err)
+}
+
+func TestLocale_GetPlural(t *testing.T) {
+	l := l10n.NewLocale("en-US")
+	l.Set("Points_zero", []string{"no points"})
+	l.Set("Points_one", []string{"%d point"})
+	l.Set("Points_other", []string{"%d points"})
+
+	assert.Equal(t, "no points", l.GetPlural("Points", 0))
+	assert.Equal(t, "1 point", l.GetPlural("Points", 1, 1))
+	assert.Equal(t, "5 points", l.GetPlural("Points", 5, 5))
+	assert.Empty(t, l.GetErrors())
+
+	fr := l10n.NewLocale("fr-FR")
+	fr.Set("Points_one", []string{"{n} point"})
+	fr.Set("Points_other", []string{"{n} points"})
+
+	assert.Equal(t, "0 point", fr.GetPlural("Points", 0, l10n.Args{"n": 0}))
+	assert.Equal(t, "1000000 points", fr.GetPlural("Points", 1000000, l10n.Args{"n": 1000000}))
+	assert.Empty(t, fr.GetErrors())
+
+	assert.Empty(t, fr.GetPlural("Missing", 1))
+	assert.Equal(t, []error{l10n.NoTranslationError{Locale: "fr-FR", Key: "Missing_other"}}, fr.GetErrors())
+}
+
+func TestPlural(t *testing.T) {
+	l := l10n.NewLocale("en-US")
+	l.Set("Points_one", []string{"%d point"})
+	l.Set("Points_other", []string{"%d points"})
+
+	assert.Equal(t, "1 point", l10n.Plural(l, "Points", 1, 1))
+
+	var loc l10n.LocaleInstance = otherLocale{l}
+	assert.Equal(t, "1 points", l10n.Plural(loc, "Points", 1, 1))
+}
+
+// otherLocale hides GetPlural of the locale.
+type otherLocale struct {
+	l10n.LocaleInstance
+}
+
+func TestRegisterPluralRule(t *testing.T) {
+	l10n.RegisterPluralRule("xx", func(op l10n.PluralOperands) l10n.PluralCategory {
+		if op.I == 2 {
+			return l10n.PluralTwo
+		}
+		return l10n.PluralOther
+	})
+
+	got, err := l10n.PluralCategoryOf("xx-YY", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, l10n.PluralTwo, got)
+}
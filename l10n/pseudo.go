@@ -0,0 +1,121 @@
+package l10n
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// PseudoConfig contains the options for Pseudo.
+type PseudoConfig struct {
+	// Name is the name of the pseudo-locale, defaults to the language with region "XA", e.g. "en-XA".
+	Name string
+	// Expansion is the ratio the text is expanded by, defaults to 0.4.
+	Expansion float64
+	// NoMarkers disables the "[" and "]" markers around each translation.
+	NoMarkers bool
+}
+
+// PseudoFunc defines the functions to be passed to Pseudo.
+type PseudoFunc func(cfg *PseudoConfig)
+
+// PseudoName sets the name of the pseudo-locale, e.g. to register it for a request locale in tests.
+func PseudoName(name string) PseudoFunc {
+	return func(cfg *PseudoConfig) {
+		cfg.Name = name
+	}
+}
+
+// PseudoExpansion sets the ratio the text is expanded by.
+func PseudoExpansion(ratio float64) PseudoFunc {
+	return func(cfg *PseudoConfig) {
+		cfg.Expansion = ratio
+	}
+}
+
+// PseudoNoMarkers disables the markers around each translation.
+func PseudoNoMarkers() PseudoFunc {
+	return func(cfg *PseudoConfig) {
+		cfg.NoMarkers = true
+	}
+}
+
+// pseudoKeep matches what is kept as is: SSML tags, entities, fmt verbs and named placeholders.
+var pseudoKeep = regexp.MustCompile(`<[^>]*>|&#?[a-zA-Z0-9]+;|` +
+	`%(?:%|[-+# 0]*(?:\[\d+\])?(?:\*|\d+)?(?:\.(?:\[\d+\])?(?:\*|\d+)?)?(?:\[\d+\])?[a-zA-Z])|` +
+	`\{\{|\}\}|\{[A-Za-z_][A-Za-z0-9_]*(?::[^}]*)?\}`)
+
+var pseudoAccents = strings.NewReplacer(
+	"a", "á", "b", "ƀ", "c", "ç", "d", "ð", "e", "é", "f", "ƒ", "g", "ĝ", "h", "ĥ", "i", "í", "j", "ĵ",
+	"k", "ķ", "l", "ļ", "m", "ɱ", "n", "ñ", "o", "ö", "p", "þ", "q", "ǫ", "r", "ŕ", "s", "š", "t", "ţ",
+	"u", "û", "v", "ṽ", "w", "ŵ", "x", "ẋ", "y", "ý", "z", "ž",
+	"A", "Å", "B", "Ɓ", "C", "Ç", "D", "Ð", "E", "É", "F", "Ƒ", "G", "Ĝ", "H", "Ĥ", "I", "Î", "J", "Ĵ",
+	"K", "Ķ", "L", "Ļ", "M", "Ṁ", "N", "Ñ", "O", "Ö", "P", "Þ", "Q", "Ǫ", "R", "Ŕ", "S", "Š", "T", "Ţ",
+	"U", "Û", "V", "Ṽ", "W", "Ŵ", "X", "Ẋ", "Y", "Ý", "Z", "Ž",
+)
+
+// Pseudo returns a pseudo-locale of the locale to find hard-coded strings and layout issues before translation.
+//
+// Letters are accented, the text is expanded and wrapped in "[" and "]" markers.
+// SSML tags, fmt verbs and named placeholders are kept, as well as URLs.
+func Pseudo(loc *Locale, opts ...PseudoFunc) *Locale {
+	cfg := PseudoConfig{Expansion: 0.4}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.Name == "" {
+		lang, _, _ := strings.Cut(loc.GetName(), "-")
+		cfg.Name = lang + "-XA"
+	}
+
+	pseudo := NewLocale(cfg.Name)
+	for key, values := range loc.GetSnippets() {
+		for i, v := range values {
+			values[i] = pseudoText(cfg, v)
+		}
+
+		pseudo.TextSnippets[key] = values
+	}
+
+	return pseudo
+}
+
+func pseudoText(cfg PseudoConfig, text string) string {
+	if text == "" || strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://") {
+		return text
+	}
+
+	var (
+		sb      strings.Builder
+		letters int
+		pos     int
+	)
+
+	for _, m := range pseudoKeep.FindAllStringIndex(text, -1) {
+		letters += utf8.RuneCountInString(text[pos:m[0]])
+		sb.WriteString(pseudoAccents.Replace(text[pos:m[0]]))
+		sb.WriteString(text[m[0]:m[1]])
+		pos = m[1]
+	}
+
+	letters += utf8.RuneCountInString(text[pos:])
+	sb.WriteString(pseudoAccents.Replace(text[pos:]))
+
+	s := sb.String()
+	pad := strings.Repeat("~", int(math.Ceil(float64(letters)*cfg.Expansion)))
+
+	start, end := "[", "]"
+	if cfg.NoMarkers {
+		start, end = "", ""
+	}
+
+	// markers and padding go inside the speak tags, so speech is still recognized as SSML
+	if strings.HasPrefix(s, "<speak>") && strings.HasSuffix(s, "</speak>") {
+		inner := strings.TrimSuffix(strings.TrimPrefix(s, "<speak>"), "</speak>")
+		return "<speak>" + start + inner + pad + end + "</speak>"
+	}
+
+	return start + s + pad + end
+}
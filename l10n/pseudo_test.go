@@ -0,0 +1,32 @@
+package l10n_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPseudo(t *testing.T) {
+	l := l10n.NewLocale("en-US")
+	l.Set("Hello", []string{"Hello %s", "Hi {name}!"})
+	l.Set("Hello_SSML", []string{`<speak>Hi <break time="1s"/> you &amp; %[1]d</speak>`})
+	l.Set(l10n.KeySkillPrivacyPolicyURL, []string{"https://example.com/privacy"})
+
+	p := l10n.Pseudo(l)
+
+	assert.Equal(t, "en-XA", p.GetName())
+	assert.Equal(t, []string{"[Ĥéļļö %s~~~]", "[Ĥí {name}!~~]"}, p.TextSnippets["Hello"])
+	assert.Equal(t, `<speak>[Ĥí <break time="1s"/> ýöû &amp; %[1]d~~~~]</speak>`, p.TextSnippets["Hello_SSML"][0])
+	assert.Equal(t, "https://example.com/privacy", p.Get(l10n.KeySkillPrivacyPolicyURL))
+	assert.Equal(t, "[Ĥéļļö Bob~~~]", p.Get("Hello", "Bob"))
+	assert.Empty(t, p.GetErrors())
+
+	// the original is unchanged
+	assert.Equal(t, "Hello %s", l.TextSnippets["Hello"][0])
+
+	r := l10n.NewRegistry()
+	assert.NoError(t, r.Register(l10n.Pseudo(l, l10n.PseudoName("en-US"), l10n.PseudoExpansion(0), l10n.PseudoNoMarkers())))
+	loc, err := r.Resolve("en-US")
+	assert.NoError(t, err)
+	assert.Equal(t, "Ĥéļļö Bob", loc.Get("Hello", "Bob"))
+}
@@ -0,0 +1,386 @@
+package l10n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Args are named arguments for translations with placeholders like "{name}".
+//
+// Pass Args as the only argument to use named placeholders instead of positional fmt verbs:
+//
+//	loc.Get("Greeting", l10n.Args{"name": "Bob"}) // "Hello {name}!" -> "Hello Bob!"
+//
+// A placeholder can define a fmt verb for its value: "{price:%.2f}".
+type Args map[string]interface{}
+
+// UnusedArgumentError defines an argument that is not used by the translation.
+type UnusedArgumentError struct {
+	Locale      string
+	Key         string
+	Placeholder string
+}
+
+// Error returns a string of the error.
+func (e UnusedArgumentError) Error() string {
+	return fmt.Sprintf("locale %s: key '%s' does not use argument '%s'", e.Locale, e.Key, e.Placeholder)
+}
+
+// GetLocale returns the locale of the error.
+func (e UnusedArgumentError) GetLocale() string {
+	return e.Locale
+}
+
+// GetKey returns the associated key.
+func (e UnusedArgumentError) GetKey() string {
+	return e.Key
+}
+
+// GetPlaceholder returns the unused argument, its name or position.
+func (e UnusedArgumentError) GetPlaceholder() string {
+	return e.Placeholder
+}
+
+// Placeholder is a placeholder in a translation.
+type Placeholder struct {
+	// Name is the name of a named placeholder.
+	Name string
+	// Index is the argument position of a fmt verb, starting at 1.
+	Index int
+	// Verb is the fmt verb, e.g. "%s" or "%.2f".
+	Verb string
+}
+
+// String returns the placeholder as written in the translation.
+func (p Placeholder) String() string {
+	if p.Name == "" {
+		return p.Verb
+	}
+
+	if p.Verb == "" {
+		return "{" + p.Name + "}"
+	}
+
+	return "{" + p.Name + ":" + p.Verb + "}"
+}
+
+// Template is a parsed translation.
+type Template struct {
+	text  string
+	parts []templatePart
+	named []Placeholder
+	verbs []Placeholder
+	err   error
+}
+
+type templatePart struct {
+	literal     string
+	placeholder *Placeholder
+}
+
+// ParseTemplate parses the named placeholders and fmt verbs of a translation.
+//
+// An error is returned if the translation contains an invalid named placeholder, the template is returned
+// nevertheless and can be executed with positional arguments.
+// Locales parse the templates of their translations once, see Locale.Set.
+func ParseTemplate(text string) (*Template, error) {
+	tmpl := &Template{text: text}
+	tmpl.parts, tmpl.named, tmpl.err = parseNamed(text)
+
+	// verbs of named placeholders are not positional
+	verbText := text
+	if tmpl.err == nil && len(tmpl.named) > 0 {
+		var sb strings.Builder
+		for _, p := range tmpl.parts {
+			sb.WriteString(p.literal)
+		}
+
+		verbText = sb.String()
+	}
+
+	tmpl.verbs = parseVerbs(verbText)
+
+	return tmpl, tmpl.err
+}
+
+// Named returns the named placeholders of the translation.
+func (t *Template) Named() []Placeholder {
+	return t.named
+}
+
+// Verbs returns the positional fmt verbs of the translation.
+func (t *Template) Verbs() []Placeholder {
+	return t.verbs
+}
+
+// Execute formats the translation with the arguments.
+//
+// With Args as only argument named placeholders are replaced, otherwise the translation is
+// formatted with fmt. Missing and unused arguments are returned as MissingPlaceholderError
+// and UnusedArgumentError.
+func (t *Template) Execute(key string, args ...interface{}) (string, error) {
+	if len(args) == 1 {
+		if named, ok := args[0].(Args); ok {
+			return t.executeNamed(key, named)
+		}
+	}
+
+	var (
+		errs   []error
+		needed int
+		used   = map[int]bool{}
+	)
+
+	for _, v := range t.verbs {
+		used[v.Index] = true
+
+		if v.Index > needed {
+			needed = v.Index
+		}
+
+		if v.Index > len(args) {
+			errs = append(errs, MissingPlaceholderError{"", key, v.Verb})
+		}
+	}
+
+	for i := range args {
+		if !used[i+1] {
+			errs = append(errs, UnusedArgumentError{"", key, strconv.Itoa(i + 1)})
+		}
+	}
+
+	if needed < len(args) {
+		args = args[:needed]
+	}
+
+	return fmt.Sprintf(t.text, args...), errors.Join(errs...)
+}
+
+func (t *Template) executeNamed(key string, args Args) (string, error) {
+	if t.err != nil {
+		return t.text, ValidationError{"", key, t.err.Error()}
+	}
+
+	var (
+		errs []error
+		used = map[string]bool{}
+		sb   strings.Builder
+	)
+
+	for _, p := range t.parts {
+		if p.placeholder == nil {
+			sb.WriteString(p.literal)
+			continue
+		}
+
+		used[p.placeholder.Name] = true
+
+		v, ok := args[p.placeholder.Name]
+		if !ok {
+			errs = append(errs, MissingPlaceholderError{"", key, p.placeholder.Name})
+			sb.WriteString(p.placeholder.String())
+
+			continue
+		}
+
+		if p.placeholder.Verb != "" {
+			_, _ = fmt.Fprintf(&sb, p.placeholder.Verb, v)
+			continue
+		}
+
+		_, _ = fmt.Fprint(&sb, v)
+	}
+
+	names := make([]string, 0, len(args))
+	for n := range args {
+		if !used[n] {
+			names = append(names, n)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, n := range names {
+		errs = append(errs, UnusedArgumentError{"", key, n})
+	}
+
+	return sb.String(), errors.Join(errs...)
+}
+
+// parseNamed splits the text into literals and named placeholders, "{{" and "}}" are escaped braces.
+// Braces only start a placeholder if they enclose a word, optionally followed by ":" and a verb.
+func parseNamed(text string) ([]templatePart, []Placeholder, error) {
+	var (
+		parts []templatePart
+		named []Placeholder
+		lit   strings.Builder
+	)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case c == '{' && strings.HasPrefix(text[i:], "{{"), c == '}' && strings.HasPrefix(text[i:], "}}"):
+			lit.WriteByte(c)
+			i++
+
+			continue
+		case c != '{':
+			lit.WriteByte(c)
+			continue
+		}
+
+		// braces not enclosing a word, e.g. "{ }" or "{\"a\": 1}", are literal
+		word := i + 1
+		for word < len(text) && isWordByte(text[word]) {
+			word++
+		}
+
+		switch {
+		case word == i+1, word < len(text) && text[word] != '}' && text[word] != ':':
+			lit.WriteByte(c)
+			continue
+		}
+
+		end := strings.IndexByte(text[i:], '}')
+		if end < 0 {
+			return nil, nil, fmt.Errorf("unclosed placeholder at position %d", i)
+		}
+
+		name, verb, _ := strings.Cut(text[i+1:i+end], ":")
+		if !isIdentifier(name) {
+			return nil, nil, fmt.Errorf("invalid placeholder '%s' at position %d", text[i:i+end+1], i)
+		}
+
+		if verb != "" && len(parseVerbs(verb)) != 1 {
+			return nil, nil, fmt.Errorf("invalid verb '%s' of placeholder '%s'", verb, name)
+		}
+
+		if lit.Len() > 0 {
+			parts = append(parts, templatePart{literal: lit.String()})
+			lit.Reset()
+		}
+
+		p := Placeholder{Name: name, Verb: verb}
+		parts = append(parts, templatePart{placeholder: &p})
+		named = append(named, p)
+		i += end
+	}
+
+	if lit.Len() > 0 {
+		parts = append(parts, templatePart{literal: lit.String()})
+	}
+
+	return parts, named, nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && c >= '0' && c <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseVerbs returns the fmt verbs of the text with the index of the argument they use.
+func parseVerbs(text string) []Placeholder {
+	var (
+		verbs []Placeholder
+		arg   = 1
+	)
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' {
+			continue
+		}
+
+		j := i + 1
+		if j < len(text) && text[j] == '%' {
+			i = j
+			continue
+		}
+
+		// flags
+		for j < len(text) && strings.IndexByte("+-# 0", text[j]) >= 0 {
+			j++
+		}
+
+		j, arg = argIndex(text, j, arg)
+
+		// width
+		if j < len(text) && text[j] == '*' {
+			verbs = append(verbs, Placeholder{Index: arg, Verb: "*"})
+			arg++
+			j++
+		}
+
+		for j < len(text) && text[j] >= '0' && text[j] <= '9' {
+			j++
+		}
+
+		// precision
+		if j < len(text) && text[j] == '.' {
+			j++
+			j, arg = argIndex(text, j, arg)
+
+			if j < len(text) && text[j] == '*' {
+				verbs = append(verbs, Placeholder{Index: arg, Verb: "*"})
+				arg++
+				j++
+			}
+
+			for j < len(text) && text[j] >= '0' && text[j] <= '9' {
+				j++
+			}
+		}
+
+		j, arg = argIndex(text, j, arg)
+
+		if j >= len(text) {
+			break
+		}
+
+		_, size := utf8.DecodeRuneInString(text[j:])
+		verbs = append(verbs, Placeholder{Index: arg, Verb: text[i : j+size]})
+		arg++
+		i = j + size - 1
+	}
+
+	return verbs
+}
+
+// argIndex parses an explicit argument index like "[2]" at position i.
+func argIndex(text string, i, arg int) (int, int) {
+	if i >= len(text) || text[i] != '[' {
+		return i, arg
+	}
+
+	end := strings.IndexByte(text[i:], ']')
+	if end < 0 {
+		return i, arg
+	}
+
+	n, err := strconv.Atoi(text[i+1 : i+end])
+	if err != nil || n < 1 {
+		return i, arg
+	}
+
+	return i + end + 1, n
+}
@@ -0,0 +1,154 @@
+package l10n_test
+
+import (
+	"errors"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		named []l10n.Placeholder
+		verbs []l10n.Placeholder
+		err   bool
+	}{
+		{
+			name: "Plain",
+			text: "Hello",
+		},
+		{
+			name:  "Named",
+			text:  "Hello {name}, you owe {price:%.2f}",
+			named: []l10n.Placeholder{{Name: "name"}, {Name: "price", Verb: "%.2f"}},
+		},
+		{
+			name:  "Verbs",
+			text:  "%s has %d%% of %[1]s",
+			verbs: []l10n.Placeholder{{Index: 1, Verb: "%s"}, {Index: 2, Verb: "%d"}, {Index: 1, Verb: "%[1]s"}},
+		},
+		{
+			name: "EscapedBraces",
+			text: "{{name}}",
+		},
+		{
+			name: "LiteralBraces",
+			text: "Say { or {} or {\"a\": 1} or {a b}",
+		},
+		{
+			name:  "NamedAndLiteralBrace",
+			text:  "Hello {name} :-{",
+			named: []l10n.Placeholder{{Name: "name"}},
+		},
+		{
+			name: "Unclosed",
+			text: "Hello {name",
+			err:  true,
+		},
+		{
+			name: "InvalidName",
+			text: "Hello {1st}",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := l10n.ParseTemplate(tt.text)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.named, tmpl.Named())
+			assert.Equal(t, tt.verbs, tmpl.Verbs())
+		})
+	}
+}
+
+func TestTemplate_Execute(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		args []interface{}
+		want string
+		errs []error
+	}{
+		{
+			name: "Named",
+			text: "Hello {name}, you owe {price:%.2f} {{EUR}}",
+			args: []interface{}{l10n.Args{"name": "Bob", "price": 1.5}},
+			want: "Hello Bob, you owe 1.50 {EUR}",
+		},
+		{
+			name: "NamedMissing",
+			text: "Hello {name}",
+			args: []interface{}{l10n.Args{}},
+			want: "Hello {name}",
+			errs: []error{l10n.MissingPlaceholderError{Key: "key", Placeholder: "name"}},
+		},
+		{
+			name: "NamedUnused",
+			text: "Hello {name}",
+			args: []interface{}{l10n.Args{"name": "Bob", "age": 3}},
+			want: "Hello Bob",
+			errs: []error{l10n.UnusedArgumentError{Key: "key", Placeholder: "age"}},
+		},
+		{
+			name: "Positional",
+			text: "Hello %s",
+			args: []interface{}{"Bob"},
+			want: "Hello Bob",
+		},
+		{
+			name: "PositionalMissing",
+			text: "Hello %s",
+			want: "Hello %!s(MISSING)",
+			errs: []error{l10n.MissingPlaceholderError{Key: "key", Placeholder: "%s"}},
+		},
+		{
+			name: "PositionalUnused",
+			text: "Hello %s",
+			args: []interface{}{"Bob", "Alice"},
+			want: "Hello Bob",
+			errs: []error{l10n.UnusedArgumentError{Key: "key", Placeholder: "2"}},
+		},
+		{
+			name: "SamplesUntouched",
+			text: "say {name}",
+			want: "say {name}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, _ := l10n.ParseTemplate(tt.text)
+
+			got, err := tmpl.Execute("key", tt.args...)
+
+			assert.Equal(t, tt.want, got)
+			if len(tt.errs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, errors.Join(tt.errs...), err)
+		})
+	}
+}
+
+func TestLocale_NamedPlaceholder(t *testing.T) {
+	l := l10n.NewLocale("en-US")
+	l.Set("Greeting", []string{"Hello {name}"})
+
+	assert.Equal(t, "Hello Bob", l.Get("Greeting", l10n.Args{"name": "Bob"}))
+	assert.Empty(t, l.GetErrors())
+
+	assert.Equal(t, "Hello {name}", l.Get("Greeting", l10n.Args{"nom": "Bob"}))
+	assert.Equal(t, []error{
+		l10n.MissingPlaceholderError{Locale: "en-US", Key: "Greeting", Placeholder: "name"},
+		l10n.UnusedArgumentError{Locale: "en-US", Key: "Greeting", Placeholder: "nom"},
+	}, l.GetErrors())
+}
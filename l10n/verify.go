@@ -0,0 +1,335 @@
+package l10n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda/ssml"
+)
+
+// PlaceholderMismatchError defines a translation with placeholders differing from the reference locale.
+type PlaceholderMismatchError struct {
+	Locale      string
+	Key         string
+	Placeholder string
+	Reference   string
+}
+
+// Error returns a string of the error.
+func (e PlaceholderMismatchError) Error() string {
+	return fmt.Sprintf("locale %s: key '%s': placeholder '%s' does not match locale %s",
+		e.Locale, e.Key, e.Placeholder, e.Reference)
+}
+
+// GetLocale returns the locale of the error.
+func (e PlaceholderMismatchError) GetLocale() string {
+	return e.Locale
+}
+
+// GetKey returns the associated key.
+func (e PlaceholderMismatchError) GetKey() string {
+	return e.Key
+}
+
+// GetPlaceholder returns the placeholder concerned.
+func (e PlaceholderMismatchError) GetPlaceholder() string {
+	return e.Placeholder
+}
+
+// UnusedKeyError defines a translation of a key that is not used.
+type UnusedKeyError struct {
+	Locale string
+	Key    string
+}
+
+// Error returns a string of the error.
+func (e UnusedKeyError) Error() string {
+	return fmt.Sprintf("locale %s: key '%s' is not used", e.Locale, e.Key)
+}
+
+// GetLocale returns the locale of the error.
+func (e UnusedKeyError) GetLocale() string {
+	return e.Locale
+}
+
+// GetKey returns the associated key.
+func (e UnusedKeyError) GetKey() string {
+	return e.Key
+}
+
+// GetPlaceholder returns the placeholder concerned.
+func (e UnusedKeyError) GetPlaceholder() string {
+	return ""
+}
+
+// VerifyConfig contains the options for Verify.
+type VerifyConfig struct {
+	Keys []string
+}
+
+// VerifyFunc defines the functions to be passed to Verify.
+type VerifyFunc func(cfg *VerifyConfig)
+
+// WithKeys verifies the locales against the keys used by the skill.
+//
+// Keys that are not translated are reported as NoTranslationError, translations of other keys as UnusedKeyError.
+func WithKeys(keys ...string) VerifyFunc {
+	return func(cfg *VerifyConfig) {
+		cfg.Keys = append(cfg.Keys, keys...)
+	}
+}
+
+// Verifier is implemented by registries that verify their locales, see Registry.Verify.
+type Verifier interface {
+	Verify(opts ...VerifyFunc) []error
+}
+
+// Verify verifies the locales of the DefaultRegistry.
+func Verify(opts ...VerifyFunc) []error {
+	v, ok := DefaultRegistry.(Verifier)
+	if !ok {
+		return []error{errors.New("l10n: default registry does not implement Verifier")}
+	}
+
+	return v.Verify(opts...)
+}
+
+// Verify compares the registered locales and returns LocaleErrors for
+//   - keys missing in a locale but translated in others (NoTranslationError),
+//   - placeholders or their verb types differing from the default locale (PlaceholderMismatchError),
+//   - invalid named placeholders and SSML that does not parse or validate (ValidationError),
+//   - keys not in the keys given by WithKeys (UnusedKeyError).
+//
+// Keys translated along the fallbacks of a locale are not missing, see Registry.Scope.
+// Plural forms like "Points_one" are uses of their key "Points" and a locale is only missing a plural form
+// if it has no "_other" form of the key. The placeholders of all translations of a key in a locale
+// are compared with those of the key in the default locale.
+func (r *Registry) Verify(opts ...VerifyFunc) []error {
+	var cfg VerifyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	r.mu.RLock()
+	names := make([]string, 0, len(r.locales))
+	for name := range r.locales {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	// the default locale is the reference for placeholders
+	names = moveFirst(names, r.defaultLocale)
+
+	snippets := make(map[string]Snippets, len(names))
+	chains := make(map[string][]Snippets, len(names))
+	for _, name := range names {
+		snippets[name] = snippetsOf(r.locales[name])
+		for _, fb := range r.chains[name] {
+			chains[name] = append(chains[name], snippetsOf(fb))
+		}
+	}
+	r.mu.RUnlock()
+
+	translated := func(name, key string) bool {
+		if len(snippets[name][key]) > 0 {
+			return true
+		}
+
+		for _, s := range chains[name] {
+			if len(s[key]) > 0 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	has := func(name, key string) bool {
+		if base, ok := pluralBase(key); ok {
+			return translated(name, key) || translated(name, base+"_"+string(PluralOther))
+		}
+
+		return translated(name, key) || used(cfg.Keys, key) && translated(name, key+"_"+string(PluralOther))
+	}
+
+	keys := map[string]bool{}
+	for _, s := range snippets {
+		for k := range s {
+			keys[k] = true
+		}
+	}
+
+	for _, k := range cfg.Keys {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+
+	sort.Strings(sorted)
+
+	var errs []error
+
+	for _, key := range sorted {
+		var (
+			ref    string
+			refSig map[string]string
+		)
+
+		for _, name := range names {
+			values := snippets[name][key]
+
+			switch {
+			case len(cfg.Keys) > 0 && !used(cfg.Keys, key):
+				if len(values) > 0 {
+					errs = append(errs, UnusedKeyError{name, key})
+				}
+
+				continue
+			case !has(name, key):
+				errs = append(errs, NoTranslationError{name, key, ""})
+				continue
+			case len(values) == 0:
+				continue
+			}
+
+			sig := map[string]string{}
+
+			for _, v := range values {
+				if _, err := ParseTemplate(v); err != nil {
+					errs = append(errs, ValidationError{name, key, err.Error()})
+				}
+
+				if err := verifySSML(name, v); err != nil {
+					errs = append(errs, ValidationError{name, key, "invalid SSML: " + err.Error()})
+				}
+
+				for k, p := range placeholderSignature(v) {
+					sig[k] = p
+				}
+			}
+
+			if refSig == nil {
+				ref, refSig = name, sig
+				continue
+			}
+
+			errs = append(errs, comparePlaceholders(name, key, ref, refSig, sig)...)
+		}
+	}
+
+	return errs
+}
+
+// used returns true if the key or, for plural forms, its base key is one of the keys.
+func used(keys []string, key string) bool {
+	base, plural := pluralBase(key)
+	for _, k := range keys {
+		if k == key || plural && k == base {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pluralBase returns the key of a plural form, e.g. "Points" for "Points_one".
+func pluralBase(key string) (string, bool) {
+	i := strings.LastIndexByte(key, '_')
+	if i < 0 {
+		return "", false
+	}
+
+	switch PluralCategory(key[i+1:]) {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return key[:i], true
+	default:
+		return "", false
+	}
+}
+
+func moveFirst(names []string, name string) []string {
+	for i, n := range names {
+		if n == name {
+			copy(names[1:i+1], names[:i])
+			names[0] = name
+
+			break
+		}
+	}
+
+	return names
+}
+
+func snippetsOf(l LocaleInstance) Snippets {
+	if s, ok := l.(interface{ GetSnippets() Snippets }); ok {
+		return s.GetSnippets()
+	}
+
+	return Snippets{}
+}
+
+// placeholderSignature returns the placeholders of the translation by name or argument position and verb type.
+func placeholderSignature(text string) map[string]string {
+	tmpl, _ := ParseTemplate(text)
+
+	sig := map[string]string{}
+	for _, p := range tmpl.Named() {
+		sig["{"+p.Name+"}"+verbType(p.Verb)] = p.String()
+	}
+
+	for _, p := range tmpl.Verbs() {
+		sig[strconv.Itoa(p.Index)+verbType(p.Verb)] = p.Verb
+	}
+
+	return sig
+}
+
+// verbType returns the type of a fmt verb, e.g. "f" for "%.2f". Named placeholders without verb use "v".
+func verbType(verb string) string {
+	if verb == "" {
+		return "v"
+	}
+
+	return verb[len(verb)-1:]
+}
+
+func comparePlaceholders(name, key, ref string, refSig, sig map[string]string) []error {
+	var diff []string
+
+	for k, p := range sig {
+		if _, ok := refSig[k]; !ok {
+			diff = append(diff, p)
+		}
+	}
+
+	for k, p := range refSig {
+		if _, ok := sig[k]; !ok {
+			diff = append(diff, p)
+		}
+	}
+
+	sort.Strings(diff)
+
+	errs := make([]error, 0, len(diff))
+	for _, p := range diff {
+		errs = append(errs, PlaceholderMismatchError{name, key, p, ref})
+	}
+
+	return errs
+}
+
+// verifySSML validates speech in <speak> tags for the locale.
+func verifySSML(locale, text string) error {
+	if !strings.HasPrefix(text, "<speak>") {
+		return nil
+	}
+
+	return ssml.Validate(text, ssml.ForLocale(locale))
+}
@@ -0,0 +1,165 @@
+package l10n_test
+
+import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newVerifyRegistry(t *testing.T) *l10n.Registry {
+	t.Helper()
+
+	enUS := l10n.NewLocale("en-US")
+	enUS.Set("Greeting", []string{"Hello %s", "Hi %s"})
+	enUS.Set("Points", []string{"{name} has {count:%d} points"})
+	enUS.Set("Bye_SSML", []string{"<speak>Bye!</speak>"})
+	enUS.Set("Old", []string{"old"})
+
+	deDE := l10n.NewLocale("de-DE")
+	deDE.Set("Greeting", []string{"Hallo %d"})
+	deDE.Set("Points", []string{"{count:%d} Punkte für {name}"})
+	deDE.Set("Bye_SSML", []string{"<speak>Tschüss & bis bald!</speak>"})
+
+	deAT := l10n.NewLocale("de-AT")
+	deAT.Set("Greeting", []string{"Servus %s"})
+
+	frFR := l10n.NewLocale("fr-FR")
+	frFR.Set("Greeting", []string{"Bonjour %s"})
+	frFR.Set("Points", []string{"{name} a {count:%d} points"})
+	frFR.Set("Bye_SSML", []string{"<speak>Au revoir!</speak>"})
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(enUS, l10n.AsDefault()))
+	assert.NoError(t, r.Register(deDE, l10n.FallbackFor("de")))
+	assert.NoError(t, r.Register(deAT))
+	assert.NoError(t, r.Register(frFR))
+
+	return r
+}
+
+func TestRegistry_Verify(t *testing.T) {
+	r := newVerifyRegistry(t)
+
+	errs := r.Verify()
+
+	assert.Len(t, errs, 4)
+	assert.Contains(t, errs, l10n.PlaceholderMismatchError{Locale: "de-DE", Key: "Greeting", Placeholder: "%d", Reference: "en-US"})
+	assert.Contains(t, errs, l10n.PlaceholderMismatchError{Locale: "de-DE", Key: "Greeting", Placeholder: "%s", Reference: "en-US"})
+	assert.Contains(t, errs, l10n.NoTranslationError{Locale: "fr-FR", Key: "Old"})
+	for _, err := range errs {
+		if e, ok := err.(l10n.ValidationError); ok {
+			assert.Equal(t, "de-DE", e.Locale)
+			assert.Equal(t, "Bye_SSML", e.Key)
+		}
+	}
+}
+
+func TestRegistry_VerifyNamedPlaceholderVerbs(t *testing.T) {
+	enUS := l10n.NewLocale("en-US")
+	enUS.Set("Points", []string{"{count:%d} points"})
+	enUS.Set("Price", []string{"{price:%.2f} EUR"})
+
+	deDE := l10n.NewLocale("de-DE")
+	deDE.Set("Points", []string{"{count} Punkte"})
+	deDE.Set("Price", []string{"{price:%.1f} EUR"})
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(enUS, l10n.AsDefault()))
+	assert.NoError(t, r.Register(deDE))
+
+	errs := r.Verify()
+
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs, l10n.PlaceholderMismatchError{Locale: "de-DE", Key: "Points", Placeholder: "{count}", Reference: "en-US"})
+	assert.Contains(t, errs, l10n.PlaceholderMismatchError{Locale: "de-DE", Key: "Points", Placeholder: "{count:%d}", Reference: "en-US"})
+}
+
+func TestVerify(t *testing.T) {
+	l10n.DefaultRegistry = newVerifyRegistry(t)
+
+	assert.Len(t, l10n.Verify(), 4)
+}
+
+func TestRegistry_VerifyInvalidPlaceholder(t *testing.T) {
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(&l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{"Greeting": {"Hello {1name}"}}}))
+
+	errs := r.Verify()
+
+	assert.Len(t, errs, 1)
+	var vErr l10n.ValidationError
+	assert.ErrorAs(t, errs[0], &vErr)
+	assert.Equal(t, "Greeting", vErr.Key)
+}
+
+func TestRegistry_VerifyWithKeys(t *testing.T) {
+	r := newVerifyRegistry(t)
+
+	errs := r.Verify(l10n.WithKeys("Greeting", "Points", "Bye_SSML", "Help"))
+
+	assert.Contains(t, errs, l10n.UnusedKeyError{Locale: "en-US", Key: "Old"})
+	assert.Contains(t, errs, l10n.NoTranslationError{Locale: "en-US", Key: "Help"})
+	assert.Contains(t, errs, l10n.NoTranslationError{Locale: "de-DE", Key: "Help"})
+	assert.Contains(t, errs, l10n.NoTranslationError{Locale: "de-AT", Key: "Help"})
+	assert.Contains(t, errs, l10n.NoTranslationError{Locale: "fr-FR", Key: "Help"})
+	assert.NotContains(t, errs, l10n.NoTranslationError{Locale: "fr-FR", Key: "Old"})
+
+	for _, err := range errs {
+		_, ok := err.(l10n.LocaleError)
+		assert.True(t, ok, err.Error())
+	}
+}
+
+func TestRegistry_VerifyFallbackChain(t *testing.T) {
+	enUS := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{"Greeting": {"Hello"}, "Help": {"Help"}}}
+	deDE := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{"Greeting": {"Hallo"}}}
+	deAT := &l10n.Locale{Name: "de-AT", TextSnippets: l10n.Snippets{}}
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(enUS, l10n.AsDefault()))
+	assert.NoError(t, r.Register(deDE, l10n.FallbackFor("de")))
+	assert.NoError(t, r.Register(deAT))
+
+	assert.Empty(t, r.Verify())
+}
+
+func TestRegistry_VerifyVariations(t *testing.T) {
+	enUS := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		"Greeting": {"Hello %s", "Hi there"},
+		"Score":    {"{name} has {count:%d} points", "{count:%d} points"},
+	}}
+	deDE := &l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{
+		"Greeting": {"Hallo %s"},
+		"Score":    {"{count:%d} Punkte"},
+	}}
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(enUS, l10n.AsDefault()))
+	assert.NoError(t, r.Register(deDE))
+
+	errs := r.Verify()
+
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs, l10n.PlaceholderMismatchError{Locale: "de-DE", Key: "Score", Placeholder: "{name}", Reference: "en-US"})
+}
+
+func TestRegistry_VerifyWithKeysPlural(t *testing.T) {
+	enUS := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		"Points_one":   {"%d point"},
+		"Points_other": {"%d points"},
+	}}
+	jaJP := &l10n.Locale{Name: "ja-JP", TextSnippets: l10n.Snippets{
+		"Points_other": {"%dポイント"},
+	}}
+
+	r := l10n.NewRegistry().(*l10n.Registry)
+	assert.NoError(t, r.Register(enUS, l10n.AsDefault()))
+	assert.NoError(t, r.Register(jaJP))
+
+	assert.Empty(t, r.Verify(l10n.WithKeys("Points")))
+
+	errs := r.Verify(l10n.WithKeys("Greeting"))
+
+	assert.Contains(t, errs, l10n.UnusedKeyError{Locale: "en-US", Key: "Points_one"})
+	assert.Contains(t, errs, l10n.UnusedKeyError{Locale: "ja-JP", Key: "Points_other"})
+}
@@ -0,0 +1,261 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ListenConfig contains the options for ListenAndServe.
+type ListenConfig struct {
+	Log            io.Writer
+	Registry       l10n.LocaleRegistry
+	Dir            string
+	ReloadInterval time.Duration
+}
+
+// ListenFunc defines the functions to be passed to ListenAndServe.
+type ListenFunc func(cfg *ListenConfig)
+
+// WithRequestLog logs a summary of each request and response to w, nil disables logging.
+func WithRequestLog(w io.Writer) ListenFunc {
+	return func(cfg *ListenConfig) {
+		cfg.Log = w
+	}
+}
+
+// WithHotReload reloads the translation files of the directory into the registry when they change,
+// checking every interval, see l10n.ReloadFS.
+func WithHotReload(registry l10n.LocaleRegistry, dir string, interval time.Duration) ListenFunc {
+	return func(cfg *ListenConfig) {
+		cfg.Registry = registry
+		cfg.Dir = dir
+		cfg.ReloadInterval = interval
+	}
+}
+
+// ListenAndServe serves the handler over HTTP on the address for local development, until the context is done.
+//
+// Requests are logged to stderr, see WithRequestLog. The server is shut down gracefully when the context is done:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	err := alexa.ListenAndServe(ctx, ":8080", mux, alexa.WithHotReload(registry, "locales", time.Second))
+func ListenAndServe(ctx context.Context, addr string, h Handler, opts ...ListenFunc) error {
+	cfg := ListenConfig{Log: os.Stderr}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           requestLogger(h, cfg.Log),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if cfg.Registry != nil && cfg.Dir != "" {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			reload(ctx, cfg)
+		}()
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+
+		_ = srv.Shutdown(shutdownCtx) //nolint:contextcheck
+	}()
+
+	if cfg.Log != nil {
+		_, _ = fmt.Fprintf(cfg.Log, "alexa: listening on %s\n", addr)
+	}
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// reload reloads the translation files when their modification time changes.
+func reload(ctx context.Context, cfg ListenConfig) {
+	interval := cfg.ReloadInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	fsys := os.DirFS(cfg.Dir)
+	last := modTime(fsys)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		mod := modTime(fsys)
+		if !mod.After(last) {
+			continue
+		}
+
+		last = mod
+
+		err := l10n.ReloadFS(cfg.Registry, fsys, ".")
+
+		switch {
+		case cfg.Log == nil:
+		case err != nil:
+			_, _ = fmt.Fprintf(cfg.Log, "alexa: reloading translations failed: %v\n", err)
+		default:
+			_, _ = fmt.Fprintf(cfg.Log, "alexa: reloaded translations of %s\n", cfg.Dir)
+		}
+	}
+}
+
+// modTime returns the latest modification time of the translation files.
+func modTime(fsys fs.FS) time.Time {
+	var last time.Time
+
+	entries, _ := fs.ReadDir(fsys, ".")
+	for _, e := range entries {
+		if _, err := l10n.FormatOf(e.Name()); err != nil {
+			continue
+		}
+
+		if info, err := e.Info(); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+
+	return last
+}
+
+// requestLogger logs a summary of each request and its response.
+func requestLogger(h Handler, w io.Writer) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || w == nil {
+			h.ServeHTTP(rw, r)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		rec := &responseRecorder{ResponseWriter: rw}
+		h.ServeHTTP(rec, r)
+
+		req := &RequestEnvelope{}
+		if err := jsoniter.Unmarshal(body, req); err != nil || req.Request == nil {
+			return
+		}
+
+		resp := &ResponseEnvelope{}
+		_ = jsoniter.Unmarshal(rec.body.Bytes(), resp)
+
+		_, _ = fmt.Fprintf(w, "→ %s\n← %s\n", summarizeRequest(req), summarizeResponse(resp))
+	})
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// summarizeRequest returns e.g. `en-US IntentRequest ColorIntent color="red"`.
+func summarizeRequest(r *RequestEnvelope) string {
+	parts := []string{r.RequestLocale(), string(r.RequestType())}
+
+	if name := r.IntentName(); name != "" {
+		parts = append(parts, name)
+	}
+
+	slots := r.Slots()
+
+	names := make([]string, 0, len(slots))
+	for name := range slots {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if v := slots[name].Value; v != "" {
+			parts = append(parts, fmt.Sprintf("%s=%q", name, v))
+		}
+	}
+
+	if s := r.RequestDialogState(); s != "" {
+		parts = append(parts, "dialog="+string(s))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// summarizeResponse returns e.g. `"Hello there" card="Hello" [Dialog.Delegate] (end)`.
+func summarizeResponse(r *ResponseEnvelope) string {
+	var parts []string
+
+	if s := r.Response.OutputSpeech; s != nil {
+		parts = append(parts, fmt.Sprintf("%q", speechText(s)))
+	}
+
+	if c := r.Response.Card; c != nil {
+		parts = append(parts, fmt.Sprintf("card=%q", c.Title))
+	}
+
+	for _, d := range r.Response.Directives {
+		parts = append(parts, "["+string(d.Type)+"]")
+	}
+
+	if r.Response.ShouldEndSession {
+		parts = append(parts, "(end)")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// speechText returns the plain text of the speech.
+func speechText(s *OutputSpeech) string {
+	if s.Type != "SSML" {
+		return s.Text
+	}
+
+	return plainText(s.SSML)
+}
@@ -0,0 +1,89 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	log "github.com/hamba/logger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestLogger(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.HandleIntentFunc("ColorIntent", func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithSpeech("<speak>Your color is <emphasis>red</emphasis></speak>").
+			WithSimpleCard("Color", "red").
+			WithShouldEndSession(true)
+	})
+
+	out := &bytes.Buffer{}
+	body := `{"version":"1.0","request":{"type":"IntentRequest","locale":"en-US","dialogState":"COMPLETED",
+		"intent":{"name":"ColorIntent","slots":{"color":{"name":"color","value":"red"},"shade":{"name":"shade"}}}}}`
+
+	rec := httptest.NewRecorder()
+	requestLogger(mux, out).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Your color is")
+	assert.Equal(t,
+		"→ en-US IntentRequest ColorIntent color=\"red\" dialog=COMPLETED\n← \"Your color is red\" card=\"Color\" (end)\n",
+		out.String())
+}
+
+func TestRequestLogger_SkipsHealthChecks(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	out := &bytes.Buffer{}
+
+	rec := httptest.NewRecorder()
+	requestLogger(mux, out).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, "ok", rec.Body.String())
+	assert.Empty(t, out.String())
+}
+
+func TestListenAndServe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.HandleRequestTypeFunc(TypeLaunchRequest, func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithSpeech("Welcome")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- ListenAndServe(ctx, addr, mux, WithRequestLog(nil))
+	}()
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		body := strings.NewReader(`{"request":{"type":"LaunchRequest","locale":"en-US"}}`)
+		resp, err = http.Post("http://"+addr, "application/json", body) //nolint:noctx
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	buf := &bytes.Buffer{}
+	_, _ = buf.ReadFrom(resp.Body)
+	_ = resp.Body.Close()
+	assert.Contains(t, buf.String(), "Welcome")
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server not shut down")
+	}
+}
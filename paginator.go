@@ -0,0 +1,161 @@
+package alexa
+
+import (
+	"fmt"
+
+	"github.com/drpsychick/go-alexa-lambda/ssml"
+)
+
+// PageAttribute is the session attribute storing the cursor of a Paginator.
+const PageAttribute = "alexa_page"
+
+// PageSource returns the speech of the content to read, e.g. an article by its key.
+type PageSource func(r *RequestEnvelope, key string) (string, error)
+
+// Paginator reads long speech over multiple turns, splitting it into pages, see ssml.Paginate.
+//
+// The cursor of the current page is stored in the session attributes, the speech is requested from the source
+// on every turn. Register the continue and repeat handlers with Register:
+//
+//	p := alexa.NewPaginator(1000, articles)
+//	p.Register(mux)
+//	mux.HandleIntentFunc("ReadIntent", func(b *alexa.ResponseBuilder, r *alexa.RequestEnvelope) {
+//		p.Read(b, r, r.SlotValue("article"))
+//	})
+type Paginator struct {
+	// Budget is the maximum number of characters of a page, including More.
+	Budget int
+	// Source returns the speech to read.
+	Source PageSource
+	// More is SSML added to pages followed by more pages and used as reprompt, e.g. "Say continue to hear more."
+	More string
+}
+
+// NewPaginator returns a paginator reading pages of at most budget characters.
+func NewPaginator(budget int, source PageSource) *Paginator {
+	return &Paginator{Budget: budget, Source: source}
+}
+
+// Register registers the continue and repeat handlers on the mux for AMAZON.NextIntent and AMAZON.RepeatIntent,
+// used only while reading.
+func (p *Paginator) Register(m *ServeMux) {
+	m.HandleIntentWhen(NextIntent, HasSessionAttribute(PageAttribute), p.ContinueHandler())
+	m.HandleIntentWhen(RepeatIntent, HasSessionAttribute(PageAttribute), p.RepeatHandler())
+}
+
+// Read responds with the first page of the content of the key.
+func (p *Paginator) Read(b *ResponseBuilder, r *RequestEnvelope, key string) {
+	p.page(b, r, key, 0)
+}
+
+// ContinueHandler returns a handler responding with the next page.
+func (p *Paginator) ContinueHandler() Handler {
+	return HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		key, page, err := cursor(r)
+		if err != nil {
+			b.WithError(err)
+			return
+		}
+
+		p.page(b, r, key, page+1)
+	})
+}
+
+// RepeatHandler returns a handler responding with the current page again.
+func (p *Paginator) RepeatHandler() Handler {
+	return HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		key, page, err := cursor(r)
+		if err != nil {
+			b.WithError(err)
+			return
+		}
+
+		p.page(b, r, key, page)
+	})
+}
+
+func (p *Paginator) page(b *ResponseBuilder, r *RequestEnvelope, key string, page int) {
+	speech, err := p.Source(r, key)
+	if err != nil {
+		b.WithError(err)
+		return
+	}
+
+	budget, err := p.budget()
+	if err != nil {
+		b.WithError(err)
+		return
+	}
+
+	pages, err := ssml.Paginate(speech, budget)
+	if err != nil {
+		b.WithError(err)
+		return
+	}
+
+	if page >= len(pages) {
+		b.WithError(fmt.Errorf("alexa: page %d of '%s' not found, %d pages", page, key, len(pages)))
+		return
+	}
+
+	attrs := map[string]interface{}{}
+	if r.Session != nil {
+		for k, v := range r.Session.Attributes {
+			attrs[k] = v
+		}
+	}
+
+	delete(attrs, PageAttribute)
+
+	text := pages[page]
+	if page < len(pages)-1 {
+		attrs[PageAttribute] = map[string]interface{}{"key": key, "page": page}
+
+		if p.More != "" {
+			text = text[:len(text)-len("</speak>")] + " " + p.More + "</speak>"
+			b.WithReprompt(ssml.Speak(p.More))
+		}
+
+		b.WithShouldEndSession(false)
+	}
+
+	b.WithSpeech(text).WithSessionAttributes(attrs)
+}
+
+// budget returns the budget of the pages, leaving room for More.
+func (p *Paginator) budget() (int, error) {
+	if p.More == "" || p.Budget <= 0 {
+		return p.Budget, nil
+	}
+
+	more, err := ssml.EstimateSpeech(ssml.Speak(" " + p.More))
+	if err != nil {
+		return 0, err
+	}
+
+	return max(p.Budget-more.Characters, 1), nil
+}
+
+// cursor returns the key and page stored in the session attributes.
+func cursor(r *RequestEnvelope) (string, int, error) {
+	if r.Session == nil {
+		return "", 0, &NotFoundError{"session attribute", PageAttribute}
+	}
+
+	c, ok := r.Session.Attributes[PageAttribute].(map[string]interface{})
+	if !ok {
+		return "", 0, &NotFoundError{"session attribute", PageAttribute}
+	}
+
+	key, _ := c["key"].(string)
+
+	// numbers are decoded as float64
+	switch page := c["page"].(type) {
+	case float64:
+		return key, int(page), nil
+	case int:
+		return key, page, nil
+	default:
+		return "", 0, &NotFoundError{"session attribute", PageAttribute}
+	}
+}
@@ -0,0 +1,112 @@
+package alexa
+
+import (
+	"errors"
+	"github.com/drpsychick/go-alexa-lambda/ssml"
+	log "github.com/hamba/logger/v2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPaginator(t *testing.T) {
+	p := NewPaginator(14, func(r *RequestEnvelope, key string) (string, error) {
+		if key != "story" {
+			return "", errors.New("unknown story")
+		}
+
+		return "<speak><p>One.</p><p>Two.</p><p>Three.</p></speak>", nil
+	})
+	p.More = "More?"
+
+	r := &RequestEnvelope{Session: &Session{Attributes: map[string]interface{}{"foo": "bar"}}}
+	b := &ResponseBuilder{}
+	p.Read(b, r, "story")
+
+	resp := b.Build()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "<speak><p>One.</p><p>Two.</p> More?</speak>", resp.Response.OutputSpeech.SSML)
+	assert.Equal(t, "<speak>More?</speak>", resp.Response.Reprompt.OutputSpeech.SSML)
+	assert.Equal(t, "bar", resp.SessionAttributes["foo"])
+	assert.False(t, resp.Response.ShouldEndSession)
+
+	// session attributes are decoded from JSON
+	r.Session.Attributes = map[string]interface{}{PageAttribute: map[string]interface{}{"key": "story", "page": 0.0}}
+	b = &ResponseBuilder{}
+	p.RepeatHandler().Serve(b, r)
+
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "<speak><p>One.</p><p>Two.</p> More?</speak>", b.Build().Response.OutputSpeech.SSML)
+
+	b = &ResponseBuilder{}
+	p.ContinueHandler().Serve(b, r)
+
+	resp = b.Build()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "<speak><p>Three.</p></speak>", resp.Response.OutputSpeech.SSML)
+	assert.NotContains(t, resp.SessionAttributes, PageAttribute)
+	assert.Nil(t, resp.Response.Reprompt)
+}
+
+func TestPaginator_Budget(t *testing.T) {
+	p := NewPaginator(40, func(r *RequestEnvelope, key string) (string, error) {
+		return "<speak>" + strings.Repeat("<s>This sentence has some words.</s>", 10) + "</speak>", nil
+	})
+	p.More = "Say continue to hear more."
+
+	r := &RequestEnvelope{Session: &Session{}}
+	b := &ResponseBuilder{}
+	p.Read(b, r, "story")
+
+	for i := 0; ; i++ {
+		resp := b.Build()
+		assert.NoError(t, b.Err())
+
+		est, err := ssml.EstimateSpeech(resp.Response.OutputSpeech.SSML)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, est.Characters, p.Budget, "page %d", i)
+
+		if _, ok := resp.SessionAttributes[PageAttribute]; !ok {
+			assert.Greater(t, i, 1)
+			break
+		}
+
+		r.Session.Attributes = resp.SessionAttributes
+		b = &ResponseBuilder{}
+		p.ContinueHandler().Serve(b, r)
+	}
+}
+
+func TestPaginator_Errors(t *testing.T) {
+	p := NewPaginator(10, func(r *RequestEnvelope, key string) (string, error) {
+		return "<speak>One.</speak>", nil
+	})
+
+	b := &ResponseBuilder{}
+	p.ContinueHandler().Serve(b, &RequestEnvelope{})
+	assert.Error(t, b.Err())
+
+	r := &RequestEnvelope{Session: &Session{Attributes: map[string]interface{}{
+		PageAttribute: map[string]interface{}{"key": "story", "page": 0},
+	}}}
+	b = &ResponseBuilder{}
+	p.ContinueHandler().Serve(b, r)
+	assert.Error(t, b.Err())
+}
+
+func TestPaginator_Register(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	NewPaginator(10, nil).Register(mux)
+
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Intent: Intent{Name: NextIntent}}}
+	_, err := mux.Handler(r)
+	assert.Error(t, err)
+
+	r.Session = &Session{Attributes: map[string]interface{}{PageAttribute: map[string]interface{}{}}}
+	_, err = mux.Handler(r)
+	assert.NoError(t, err)
+
+	r.Request.Intent.Name = RepeatIntent
+	_, err = mux.Handler(r)
+	assert.NoError(t, err)
+}
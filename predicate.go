@@ -0,0 +1,122 @@
+package alexa
+
+import (
+	"reflect"
+)
+
+// Predicate reports whether a request matches a condition.
+type Predicate func(r *RequestEnvelope) bool
+
+// And matches when all given predicates match.
+func And(preds ...Predicate) Predicate {
+	return func(r *RequestEnvelope) bool {
+		for _, p := range preds {
+			if !p(r) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or matches when any of the given predicates matches.
+func Or(preds ...Predicate) Predicate {
+	return func(r *RequestEnvelope) bool {
+		for _, p := range preds {
+			if p(r) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not negates the given predicate.
+func Not(pred Predicate) Predicate {
+	return func(r *RequestEnvelope) bool {
+		return !pred(r)
+	}
+}
+
+// DialogState matches requests with the given dialog state.
+func DialogState(state DialogStateType) Predicate {
+	return func(r *RequestEnvelope) bool {
+		return r.RequestDialogState() == state
+	}
+}
+
+// HasSlot matches requests where the named slot is present and has a value.
+func HasSlot(name string) Predicate {
+	return func(r *RequestEnvelope) bool {
+		return r.SlotValue(name) != ""
+	}
+}
+
+// SlotValueIs matches requests where the named slot has the given value.
+func SlotValueIs(name, value string) Predicate {
+	return func(r *RequestEnvelope) bool {
+		s, err := r.Slot(name)
+		if err != nil {
+			return false
+		}
+
+		return s.Value == value
+	}
+}
+
+// HasSessionAttribute matches requests where the session contains the given attribute.
+func HasSessionAttribute(key string) Predicate {
+	return func(r *RequestEnvelope) bool {
+		if r.Session == nil || r.Session.Attributes == nil {
+			return false
+		}
+
+		_, ok := r.Session.Attributes[key]
+
+		return ok
+	}
+}
+
+// SessionAttributeIs matches requests where the session attribute equals the given value.
+//
+// Keep in mind that numbers in session attributes are decoded as float64.
+func SessionAttributeIs(key string, value interface{}) Predicate {
+	return func(r *RequestEnvelope) bool {
+		if r.Session == nil || r.Session.Attributes == nil {
+			return false
+		}
+
+		v, ok := r.Session.Attributes[key]
+
+		return ok && reflect.DeepEqual(v, value)
+	}
+}
+
+// Locale matches requests in any of the given locales.
+func Locale(locales ...RequestLocale) Predicate {
+	return func(r *RequestEnvelope) bool {
+		for _, l := range locales {
+			if r.RequestLocale() == string(l) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// SupportsInterface matches requests from devices supporting the given interface (e.g. "AudioPlayer").
+func SupportsInterface(name string) Predicate {
+	return func(r *RequestEnvelope) bool {
+		s, err := r.System()
+		if err != nil {
+			return false
+		}
+
+		_, ok := s.Device.SupportedInterfaces[name]
+
+		return ok
+	}
+}
@@ -0,0 +1,68 @@
+package alexa
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	r := &RequestEnvelope{
+		Session: &Session{Attributes: map[string]interface{}{"step": "ask", "count": float64(2)}},
+		Context: &Context{System: &ContextSystem{}},
+		Request: &Request{
+			Type:        TypeIntentRequest,
+			Locale:      LocaleGerman,
+			DialogState: DialogStateCompleted,
+			Intent: Intent{
+				Name:  "Intent",
+				Slots: map[string]*Slot{"color": {Name: "color", Value: "red"}, "empty": {Name: "empty"}},
+			},
+		},
+	}
+	r.Context.System.Device.SupportedInterfaces = map[string]struct{}{"AudioPlayer": {}}
+
+	tests := []struct {
+		name string
+		pred Predicate
+		want bool
+	}{
+		{"DialogState", DialogState(DialogStateCompleted), true},
+		{"DialogStateOther", DialogState(DialogStateStarted), false},
+		{"HasSlot", HasSlot("color"), true},
+		{"HasSlotEmpty", HasSlot("empty"), false},
+		{"HasSlotMissing", HasSlot("size"), false},
+		{"SlotValueIs", SlotValueIs("color", "red"), true},
+		{"SlotValueIsOther", SlotValueIs("color", "blue"), false},
+		{"HasSessionAttribute", HasSessionAttribute("step"), true},
+		{"HasSessionAttributeMissing", HasSessionAttribute("foo"), false},
+		{"SessionAttributeIs", SessionAttributeIs("step", "ask"), true},
+		{"SessionAttributeIsNumber", SessionAttributeIs("count", float64(2)), true},
+		{"SessionAttributeIsOther", SessionAttributeIs("step", "tell"), false},
+		{"Locale", Locale(LocaleAmericanEnglish, LocaleGerman), true},
+		{"LocaleOther", Locale(LocaleAmericanEnglish), false},
+		{"SupportsInterface", SupportsInterface("AudioPlayer"), true},
+		{"SupportsInterfaceMissing", SupportsInterface("Display"), false},
+		{"And", And(HasSlot("color"), Locale(LocaleGerman)), true},
+		{"AndFails", And(HasSlot("color"), Locale(LocaleFrench)), false},
+		{"Or", Or(HasSlot("size"), Locale(LocaleGerman)), true},
+		{"OrFails", Or(HasSlot("size"), Locale(LocaleFrench)), false},
+		{"Not", Not(HasSlot("size")), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pred(r))
+		})
+	}
+}
+
+func TestPredicates_EmptyRequest(t *testing.T) {
+	r := &RequestEnvelope{}
+
+	assert.False(t, DialogState(DialogStateCompleted)(r))
+	assert.False(t, HasSlot("color")(r))
+	assert.False(t, SlotValueIs("color", "")(r))
+	assert.False(t, HasSessionAttribute("step")(r))
+	assert.False(t, SessionAttributeIs("step", nil)(r))
+	assert.False(t, Locale(LocaleGerman)(r))
+	assert.False(t, SupportsInterface("AudioPlayer")(r))
+}
@@ -0,0 +1,112 @@
+package alexa
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Redacted replaces tokens and user ids in recorded requests.
+const Redacted = "REDACTED"
+
+// Recording is a request and the response of the skill, recorded as one line of JSON, see Recorder.
+type Recording struct {
+	Request  *RequestEnvelope  `json:"request"`
+	Response *ResponseEnvelope `json:"response"`
+}
+
+// Recorder is a handler recording the requests and responses of the wrapped handler as JSON lines,
+// e.g. to replay production traffic against a new build, see alexatest.Replay.
+//
+// Access tokens, user and person ids of the requests are replaced with Redacted.
+type Recorder struct {
+	handler Handler
+
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewRecorder returns a recorder of the handler writing to w, e.g. a file opened for appending.
+func NewRecorder(h Handler, w io.Writer) *Recorder {
+	return &Recorder{handler: h, w: w}
+}
+
+// Serve serves the request with the wrapped handler and records it with the response.
+func (rec *Recorder) Serve(b *ResponseBuilder, r *RequestEnvelope) {
+	req := Redact(r)
+
+	rec.handler.Serve(b, r)
+
+	line, err := jsoniter.Marshal(Recording{Request: req, Response: b.Build()})
+	if err == nil {
+		line = append(line, '\n')
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if err == nil {
+		_, err = rec.w.Write(line)
+	}
+
+	if err != nil && rec.err == nil {
+		rec.err = err
+	}
+}
+
+// ServeHTTP serves a HTTP request.
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	HandlerFunc(rec.Serve).ServeHTTP(w, r)
+}
+
+// Err returns the first error recording a request.
+func (rec *Recorder) Err() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return rec.err
+}
+
+// Redact returns a copy of the request with access tokens, user and person ids replaced with Redacted.
+func Redact(r *RequestEnvelope) *RequestEnvelope {
+	req := &RequestEnvelope{}
+	if b, err := jsoniter.Marshal(r); err != nil || jsoniter.Unmarshal(b, req) != nil {
+		return &RequestEnvelope{Version: r.Version}
+	}
+
+	if req.Session != nil && req.Session.User != nil {
+		redactUser(req.Session.User)
+	}
+
+	if req.Context == nil || req.Context.System == nil {
+		return req
+	}
+
+	sys := req.Context.System
+	redact(&sys.APIAccessToken)
+
+	if sys.User != nil {
+		redactUser(sys.User)
+	}
+
+	if sys.Person != nil {
+		redact(&sys.Person.PersonID)
+		redact(&sys.Person.AccessToken)
+	}
+
+	return req
+}
+
+func redactUser(u *ContextUser) {
+	redact(&u.UserID)
+	redact(&u.AccessToken)
+}
+
+func redact(s *string) {
+	if *s != "" {
+		*s = Redacted
+	}
+}
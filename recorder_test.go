@@ -0,0 +1,83 @@
+package alexa
+
+import (
+	"bytes"
+	"errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	out := &bytes.Buffer{}
+	rec := NewRecorder(HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithSpeech("Hello " + r.Session.User.UserID)
+	}), out)
+
+	r := &RequestEnvelope{
+		Version: "1.0",
+		Session: &Session{User: &ContextUser{UserID: "amzn1.ask.account.1", AccessToken: "secret"}},
+		Context: &Context{System: &ContextSystem{
+			APIAccessToken: "secret",
+			User:           &ContextUser{UserID: "amzn1.ask.account.1"},
+			Person:         &ContextSystemPerson{PersonID: "amzn1.ask.person.1"},
+		}},
+		Request: &Request{Type: TypeLaunchRequest, RequestID: "req.1"},
+	}
+
+	b := &ResponseBuilder{}
+	rec.Serve(b, r)
+
+	assert.Equal(t, "Hello amzn1.ask.account.1", b.Build().Response.OutputSpeech.Text)
+	assert.Equal(t, "amzn1.ask.account.1", r.Session.User.UserID)
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "amzn1.ask.person.1")
+	assert.True(t, strings.HasSuffix(out.String(), "}\n"))
+
+	got := Recording{}
+	require.NoError(t, jsoniter.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "req.1", got.Request.Request.RequestID)
+	assert.Equal(t, Redacted, got.Request.Session.User.UserID)
+	assert.Equal(t, Redacted, got.Request.Context.System.APIAccessToken)
+	assert.Equal(t, Redacted, got.Request.Context.System.Person.PersonID)
+	assert.Empty(t, got.Request.Context.System.Person.AccessToken)
+	assert.Equal(t, "Hello amzn1.ask.account.1", got.Response.Response.OutputSpeech.Text)
+	assert.NoError(t, rec.Err())
+}
+
+func TestRecorder_ServeHTTP(t *testing.T) {
+	out := &bytes.Buffer{}
+	rec := NewRecorder(HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithSpeech("Welcome")
+	}), out)
+
+	rr := httptest.NewRecorder()
+	body := `{"version":"1.0","request":{"type":"LaunchRequest"}}`
+	rec.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	assert.Contains(t, rr.Body.String(), "Welcome")
+	assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+	assert.Contains(t, out.String(), `"type":"LaunchRequest"`)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecorder_Err(t *testing.T) {
+	rec := NewRecorder(HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithSpeech("Welcome")
+	}), failingWriter{})
+
+	b := &ResponseBuilder{}
+	rec.Serve(b, &RequestEnvelope{Request: &Request{Type: TypeLaunchRequest}})
+
+	assert.Equal(t, "Welcome", b.Build().Response.OutputSpeech.Text)
+	assert.EqualError(t, rec.Err(), "disk full")
+}
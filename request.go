@@ -3,6 +3,8 @@ package alexa
 import (
 	"errors"
 	"fmt"
+
+	"github.com/drpsychick/go-alexa-lambda/l10n"
 )
 
 // NotFoundError defines a generic not found error.
@@ -24,6 +26,7 @@ func (e NotFoundError) Error() string {
 var (
 	ErrUnknown                   = errors.New("unknown error")
 	ErrSlotNoResolutionWithMatch = errors.New("no resolution with match")
+	ErrNoLocale                  = errors.New("no locale to localize response")
 )
 
 // RequestLocale represents the locale of the request.
@@ -92,6 +95,15 @@ const (
 
 	// StopIntent is the Alexa built-in Stop Intent.
 	StopIntent = "AMAZON.StopIntent"
+
+	// FallbackIntent is the Alexa built-in Fallback Intent.
+	FallbackIntent = "AMAZON.FallbackIntent"
+
+	// NextIntent is the Alexa built-in Next Intent.
+	NextIntent = "AMAZON.NextIntent"
+
+	// RepeatIntent is the Alexa built-in Repeat Intent.
+	RepeatIntent = "AMAZON.RepeatIntent"
 )
 
 // Intent is the Alexa skill intent.
@@ -103,6 +115,10 @@ type Intent struct {
 
 // Intent returns the intent or an empty intent.
 func (r *RequestEnvelope) Intent() (Intent, error) {
+	if r.Request == nil {
+		return Intent{}, &NotFoundError{"intent", ""}
+	}
+
 	i := r.Request.Intent
 	if i.Name == "" {
 		return Intent{}, &NotFoundError{"intent", ""}
@@ -297,6 +313,11 @@ func (r *RequestEnvelope) RequestLocale() string {
 	return string(r.Request.Locale)
 }
 
+// Locale returns the locale resolved for the request by the ServeMux, or nil.
+func (r *RequestEnvelope) Locale() l10n.LocaleInstance {
+	return r.locale
+}
+
 // RequestDialogState returns the dialog state of the request.
 func (r *RequestEnvelope) RequestDialogState() DialogStateType {
 	if r.Request == nil {
@@ -569,4 +590,6 @@ type RequestEnvelope struct {
 	Session *Session `json:"session"`
 	Context *Context `json:"context"`
 	Request *Request `json:"request"`
+
+	locale l10n.LocaleInstance
 }
@@ -1,8 +1,12 @@
 package alexa
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/drpsychick/go-alexa-lambda/l10n"
+	"github.com/drpsychick/go-alexa-lambda/ssml"
 )
 
 // Stream represents a response directive audio item stream.
@@ -104,6 +108,10 @@ type ResponseBuilder struct {
 	shouldEndSession bool
 	sessionAttr      map[string]interface{}
 	canFulfillIntent *CanFulfillIntent
+	err              error
+	deriveText       bool
+	validate         bool
+	validateOpts     []ssml.ValidateFunc
 }
 
 // With applies an Response.
@@ -128,45 +136,138 @@ func (b *ResponseBuilder) With(resp Response) {
 	b.WithShouldEndSession(resp.End)
 }
 
+// WithLocalized sets a simple card and the speech from the translations of the given key.
+//
+// The title, text and speech are looked up with the l10n.KeyPostfixTitle, l10n.KeyPostfixText
+// and l10n.KeyPostfixSSML postfix, args are passed to text and speech.
+// Translation errors are recorded on the builder, see Err. An argument is only reported as unused
+// if neither text nor speech uses it, e.g. a card text may leave out the name of the speech.
+func (b *ResponseBuilder) WithLocalized(loc l10n.LocaleInstance, key string, args ...interface{}) *ResponseBuilder {
+	if loc == nil {
+		return b.WithError(ErrNoLocale)
+	}
+
+	n := len(loc.GetErrors())
+
+	title := loc.GetAny(key + l10n.KeyPostfixTitle)
+	text := loc.GetAny(key+l10n.KeyPostfixText, args...)
+	speech := loc.GetAny(key+l10n.KeyPostfixSSML, args...)
+
+	if errs := localizedErrors(loc.GetErrors()[n:]); len(errs) > 0 {
+		b.WithError(errors.Join(errs...))
+	}
+
+	b.speech = b.outputSpeech(speech, loc.GetName())
+
+	return b.WithSimpleCard(title, text)
+}
+
+// localizedErrors returns the errors of the text and speech of WithLocalized,
+// without arguments unused by only one of them.
+func localizedErrors(errs []error) []error {
+	unused := map[string]int{}
+
+	for _, err := range errs {
+		var u l10n.UnusedArgumentError
+		if errors.As(err, &u) {
+			unused[u.Placeholder]++
+		}
+	}
+
+	var out []error
+
+	for _, err := range errs {
+		var u l10n.UnusedArgumentError
+		if errors.As(err, &u) && unused[u.Placeholder] < 2 {
+			continue
+		}
+
+		out = append(out, err)
+	}
+
+	return out
+}
+
+// WithError records an error on the response.
+//
+// When served by a ServeMux, a response with an error is replaced by the response of the error handler.
+func (b *ResponseBuilder) WithError(err error) *ResponseBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+}
+
+// Err returns the first error recorded on the response.
+func (b *ResponseBuilder) Err() error {
+	return b.err
+}
+
 // WithSpeech sets the output speech on the response.
 //
 // If the text contains SSML speak tags, it will be set as SSML speech,
 // otherwise it will be set as plain text speech.
+// With WithSpeechValidation, invalid SSML is recorded as error on the builder, see Err.
 func (b *ResponseBuilder) WithSpeech(text string) *ResponseBuilder {
-	if strings.HasPrefix(text, "<speak>") && strings.HasSuffix(text, "</speak>") {
-		b.speech = &OutputSpeech{
-			Type: "SSML",
-			SSML: text,
-		}
+	b.speech = b.outputSpeech(text, "")
+	return b
+}
 
-		return b
+// WithSSML sets the SSML document as output speech on the response.
+//
+// Invalid attribute values of the document are recorded on the builder, see Err.
+func (b *ResponseBuilder) WithSSML(doc *ssml.Builder) *ResponseBuilder {
+	if err := doc.Err(); err != nil {
+		b.WithError(err)
 	}
 
-	b.speech = &OutputSpeech{
-		Type: "PlainText",
-		Text: text,
-	}
+	return b.WithSpeech(doc.String())
+}
+
+// WithSpeechValidation validates SSML speech set afterwards against the rules of Alexa (see ssml.Validate)
+// and records invalid speech as error on the builder, see Err.
+//
+// Options add rules, e.g. ssml.WithMaxDuration to keep speech short before a reprompt.
+// Speech is not validated without WithSpeechValidation.
+func (b *ResponseBuilder) WithSpeechValidation(opts ...ssml.ValidateFunc) *ResponseBuilder {
+	b.validate = true
+	b.validateOpts = append(b.validateOpts, opts...)
 
 	return b
 }
 
 // WithReprompt sets the reprompt output speech on the response.
 func (b *ResponseBuilder) WithReprompt(text string) *ResponseBuilder {
-	if strings.HasPrefix(text, "<speak>") && strings.HasSuffix(text, "</speak>") {
-		b.reprompt = &OutputSpeech{
-			Type: "SSML",
-			SSML: text,
-		}
+	b.reprompt = b.outputSpeech(text, "")
+	return b
+}
 
-		return b
+// outputSpeech returns SSML or plain text speech, validating SSML for the locale if given
+// and enabled with WithSpeechValidation.
+func (b *ResponseBuilder) outputSpeech(text, locale string) *OutputSpeech {
+	if !strings.HasPrefix(text, "<speak>") || !strings.HasSuffix(text, "</speak>") {
+		return &OutputSpeech{
+			Type: "PlainText",
+			Text: text,
+		}
 	}
 
-	b.reprompt = &OutputSpeech{
-		Type: "PlainText",
-		Text: text,
+	if b.validate {
+		opts := append([]ssml.ValidateFunc(nil), b.validateOpts...)
+		if locale != "" {
+			opts = append(opts, ssml.ForLocale(locale))
+		}
+
+		if err := ssml.Validate(text, opts...); err != nil {
+			b.WithError(err)
+		}
 	}
 
-	return b
+	return &OutputSpeech{
+		Type: "SSML",
+		SSML: text,
+	}
 }
 
 // WithSimpleCard sets a simple card on the response.
@@ -192,6 +293,68 @@ func (b *ResponseBuilder) WithStandardCard(title, text string, image *Image) *Re
 	return b
 }
 
+// WithDerivedText derives the card content and the plain text of SSML speech and reprompt from the speech.
+//
+// The card content is only derived if the card has no content, a simple card is added if the response has no card.
+// SSML speech keeps its type and gets the plain text as fallback in Text, see ssml.ToPlainText.
+func (b *ResponseBuilder) WithDerivedText() *ResponseBuilder {
+	b.deriveText = true
+	return b
+}
+
+// plainText returns the speech as plain text.
+func plainText(speech string) string {
+	if !strings.HasPrefix(speech, "<speak>") || !strings.HasSuffix(speech, "</speak>") {
+		return speech
+	}
+
+	text, err := ssml.ToPlainText(speech)
+	if err != nil {
+		return ""
+	}
+
+	return text
+}
+
+// derivedSpeech returns the SSML speech with the plain text derived from it as fallback.
+func (b *ResponseBuilder) derivedSpeech(speech *OutputSpeech) *OutputSpeech {
+	if !b.deriveText || speech == nil || speech.Type != "SSML" || speech.Text != "" {
+		return speech
+	}
+
+	s := *speech
+	s.Text = plainText(s.SSML)
+
+	return &s
+}
+
+// derivedCard returns the card with the content derived from the speech.
+func (b *ResponseBuilder) derivedCard() *Card {
+	if !b.deriveText || b.speech == nil {
+		return b.card
+	}
+
+	text := b.speech.Text
+	if b.speech.Type == "SSML" {
+		text = plainText(b.speech.SSML)
+	}
+
+	if b.card == nil {
+		return &Card{Type: "Simple", Content: text}
+	}
+
+	card := *b.card
+
+	switch {
+	case card.Type == "Standard" && card.Text == "":
+		card.Text = text
+	case card.Type != "Standard" && card.Content == "":
+		card.Content = text
+	}
+
+	return &card
+}
+
 // WithShouldEndSession determines if the session should end after the current response.
 func (b *ResponseBuilder) WithShouldEndSession(end bool) *ResponseBuilder {
 	b.shouldEndSession = end
@@ -225,8 +388,8 @@ func (b *ResponseBuilder) Build() *ResponseEnvelope {
 		Version:           "1.0",
 		SessionAttributes: b.sessionAttr,
 		Response: response{
-			OutputSpeech:     b.speech,
-			Card:             b.card,
+			OutputSpeech:     b.derivedSpeech(b.speech),
+			Card:             b.derivedCard(),
 			Directives:       b.directives,
 			ShouldEndSession: b.shouldEndSession,
 		},
@@ -234,7 +397,7 @@ func (b *ResponseBuilder) Build() *ResponseEnvelope {
 
 	if b.reprompt != nil {
 		r.Response.Reprompt = &Reprompt{
-			OutputSpeech: b.reprompt,
+			OutputSpeech: b.derivedSpeech(b.reprompt),
 		}
 	}
 
@@ -1,10 +1,12 @@
 package alexa
 
 import (
+	"github.com/drpsychick/go-alexa-lambda/l10n"
 	"github.com/drpsychick/go-alexa-lambda/ssml"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestWith_Functions(t *testing.T) {
@@ -131,3 +133,156 @@ func TestResponseBuilder_With(t *testing.T) {
 		})
 	}
 }
+
+func TestWithLocalized(t *testing.T) {
+	loc := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		"Greet_Title": {"Hello"},
+		"Greet_Text":  {"Hello %s"},
+		"Greet_SSML":  {"<speak>Hello %s</speak>"},
+		"Half_Title":  {"Half"},
+	}}
+	b := &ResponseBuilder{}
+
+	b.WithLocalized(loc, "Greet", "Bob")
+
+	res := b.Build()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "Hello", res.Response.Card.Title)
+	assert.Equal(t, "Hello Bob", res.Response.Card.Content)
+	assert.Equal(t, "<speak>Hello Bob</speak>", res.Response.OutputSpeech.SSML)
+
+	b = &ResponseBuilder{}
+	b.WithLocalized(loc, "Half")
+
+	var l10nErr l10n.LocaleError
+	assert.Error(t, b.Err())
+	assert.ErrorAs(t, b.Err(), &l10nErr)
+	assert.Equal(t, "Half_Text", l10nErr.GetKey())
+	assert.Contains(t, b.Err().Error(), "Half_SSML")
+
+	b = &ResponseBuilder{}
+	b.WithLocalized(nil, "Greet")
+	assert.ErrorIs(t, b.Err(), ErrNoLocale)
+}
+
+func TestWithLocalized_UnusedArguments(t *testing.T) {
+	loc := &l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		"Greet_Title": {"Hello"},
+		"Greet_Text":  {"Hello!"},
+		"Greet_SSML":  {"<speak>Hello %s!</speak>"},
+		"Plain_Title": {"Plain"},
+		"Plain_Text":  {"Hello!"},
+		"Plain_SSML":  {"<speak>Hello!</speak>"},
+	}}
+
+	b := &ResponseBuilder{}
+	b.WithLocalized(loc, "Greet", "Bob")
+
+	res := b.Build()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "Hello!", res.Response.Card.Content)
+	assert.Equal(t, "<speak>Hello Bob!</speak>", res.Response.OutputSpeech.SSML)
+
+	b = &ResponseBuilder{}
+	b.WithLocalized(loc, "Plain", "Bob")
+
+	var unused l10n.UnusedArgumentError
+	assert.ErrorAs(t, b.Err(), &unused)
+	assert.Equal(t, "1", unused.Placeholder)
+}
+
+func TestWithError(t *testing.T) {
+	b := &ResponseBuilder{}
+	assert.NoError(t, b.Err())
+
+	b.WithError(ErrUnknown).WithError(ErrNoLocale)
+
+	assert.Equal(t, ErrUnknown, b.Err())
+}
+
+func TestWithSSML(t *testing.T) {
+	b := &ResponseBuilder{}
+	b.WithSSML(ssml.New().S(ssml.Text("Tom & Jerry")))
+
+	resp := b.Build()
+
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "SSML", resp.Response.OutputSpeech.Type)
+	assert.Equal(t, "<speak><s>Tom &amp; Jerry</s></speak>", resp.Response.OutputSpeech.SSML)
+
+	b = &ResponseBuilder{}
+	b.WithSSML(ssml.New().Break("", "20s"))
+
+	assert.Error(t, b.Err())
+}
+
+func TestWithSpeech_InvalidSSML(t *testing.T) {
+	b := &ResponseBuilder{}
+	b.WithSpeech(`<speak><break time="20s"/></speak>`)
+
+	assert.NoError(t, b.Err())
+
+	b = &ResponseBuilder{}
+	b.WithSpeechValidation().WithSpeech(`<speak><break time="20s"/></speak>`)
+
+	assert.Error(t, b.Err())
+
+	b = &ResponseBuilder{}
+	b.WithSpeechValidation().WithReprompt("<speak>Tom & Jerry</speak>")
+
+	assert.Error(t, b.Err())
+}
+
+func TestWithDerivedText(t *testing.T) {
+	b := &ResponseBuilder{}
+	b.WithSpeech(`<speak>Call <say-as interpret-as="telephone">5551212</say-as><audio src="soundbank://x"/>.</speak>`).
+		WithDerivedText()
+
+	resp := b.Build()
+
+	assert.Equal(t, "Simple", resp.Response.Card.Type)
+	assert.Equal(t, "Call 555-1212.", resp.Response.Card.Content)
+	assert.Equal(t, "SSML", resp.Response.OutputSpeech.Type)
+	assert.Equal(t, "Call 555-1212.", resp.Response.OutputSpeech.Text)
+	assert.Empty(t, b.speech.Text)
+
+	b.WithReprompt(`<speak><sub alias="Doctor">Dr.</sub> Who?</speak>`)
+	resp = b.Build()
+
+	assert.Equal(t, "Doctor Who?", resp.Response.Reprompt.OutputSpeech.Text)
+
+	b.WithStandardCard("title", "", nil)
+	resp = b.Build()
+
+	assert.Equal(t, "Call 555-1212.", resp.Response.Card.Text)
+	assert.Empty(t, b.card.Text)
+
+	b.WithSimpleCard("title", "text")
+	resp = b.Build()
+
+	assert.Equal(t, "text", resp.Response.Card.Content)
+}
+
+func TestResponseBuilder_WithDerivedText(t *testing.T) {
+	b := &ResponseBuilder{}
+	b.With(Response{Title: "title", Speech: "<speak><sub alias=\"Doctor\">Dr.</sub> Who</speak>"})
+
+	assert.Empty(t, b.Build().Response.Card.Content)
+
+	b.WithDerivedText()
+
+	assert.Equal(t, "Doctor Who", b.Build().Response.Card.Content)
+}
+
+func TestWithSpeechValidation(t *testing.T) {
+	b := &ResponseBuilder{}
+	b.WithSpeechValidation(ssml.WithMaxDuration(5 * time.Second)).
+		WithSpeech(`<speak>Hello<break time="10s"/></speak>`)
+
+	assert.Error(t, b.Err())
+
+	b = &ResponseBuilder{}
+	b.WithSpeechValidation().WithSpeech(`<speak>Hello<break time="10s"/></speak>`)
+
+	assert.NoError(t, b.Err())
+}
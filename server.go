@@ -6,10 +6,12 @@ import (
 	"io"
 	l2 "log"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
 	log "github.com/hamba/logger/v2"
 	lctx "github.com/hamba/logger/v2/ctx"
 	jsoniter "github.com/json-iterator/go"
@@ -62,6 +64,19 @@ func (fn HandlerFunc) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	_, _ = rw.Write(resp)
 }
 
+// ErrorHandler represents a handler for errors that occurred while serving a request.
+type ErrorHandler interface {
+	ServeError(builder *ResponseBuilder, req *RequestEnvelope, err error)
+}
+
+// ErrorHandlerFunc is an adapter allowing a function to be used as an error handler.
+type ErrorHandlerFunc func(*ResponseBuilder, *RequestEnvelope, error)
+
+// ServeError serves the error.
+func (fn ErrorHandlerFunc) ServeError(b *ResponseBuilder, r *RequestEnvelope, err error) {
+	fn(b, r, err)
+}
+
 // A Server defines parameters for running an Alexa server.
 type Server struct {
 	Handler Handler
@@ -101,22 +116,34 @@ func Serve(h Handler) error {
 	return srv.Serve()
 }
 
+// route is a handler that is only used when its predicate matches.
+type route struct {
+	when     Predicate
+	priority int
+	handler  Handler
+}
+
 // ServeMux is an Alexa request multiplexer.
 type ServeMux struct {
-	mu          sync.RWMutex
-	logger      *log.Logger
-	types       map[RequestType]Handler
-	intents     map[string]Handler
-	intentSlots map[string]string
+	mu           sync.RWMutex
+	logger       *log.Logger
+	types        map[RequestType]Handler
+	intents      map[string]Handler
+	intentRoutes map[string][]route
+	intentSlots  map[string]string
+	registry     l10n.LocaleRegistry
+	notFound     Handler
+	errHandler   ErrorHandler
 }
 
 // NewServerMux creates a new server mux.
 func NewServerMux(log *log.Logger) *ServeMux {
 	return &ServeMux{
-		logger:      log,
-		types:       map[RequestType]Handler{},
-		intents:     map[string]Handler{},
-		intentSlots: map[string]string{},
+		logger:       log,
+		types:        map[RequestType]Handler{},
+		intents:      map[string]Handler{},
+		intentRoutes: map[string][]route{},
+		intentSlots:  map[string]string{},
 	}
 }
 
@@ -125,6 +152,29 @@ func (m *ServeMux) Logger() *log.Logger {
 	return m.logger
 }
 
+// SetLocaleRegistry sets the locale registry used by the default handlers.
+//
+// If no registry is set, l10n.DefaultRegistry is used.
+func (m *ServeMux) SetLocaleRegistry(registry l10n.LocaleRegistry) {
+	m.mu.Lock()
+
+	m.registry = registry
+
+	m.mu.Unlock()
+}
+
+// LocaleRegistry returns the locale registry of the mux.
+func (m *ServeMux) LocaleRegistry() l10n.LocaleRegistry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.registry == nil {
+		return l10n.DefaultRegistry
+	}
+
+	return m.registry
+}
+
 // Handler returns the matched handler for a request, or an error.
 func (m *ServeMux) Handler(r *RequestEnvelope) (Handler, error) {
 	m.mu.RLock()
@@ -138,6 +188,12 @@ func (m *ServeMux) Handler(r *RequestEnvelope) (Handler, error) {
 		return nil, fmt.Errorf("server: unknown intent type %s", r.RequestType())
 	}
 
+	for _, rt := range m.intentRoutes[r.IntentName()] {
+		if rt.when(r) {
+			return rt.handler, nil
+		}
+	}
+
 	h, ok := m.intents[r.IntentName()]
 	if !ok {
 		return nil, fmt.Errorf("server: unknown intent %s", r.IntentName())
@@ -190,25 +246,195 @@ func (m *ServeMux) HandleIntentFunc(intent string, handler HandlerFunc) {
 	m.HandleIntent(intent, handler)
 }
 
-// fallbackHandler returns a fatal error card.
-func fallbackHandler(err error) HandlerFunc {
-	return HandlerFunc(func(b *ResponseBuilder, _ *RequestEnvelope) {
-		b.WithSimpleCard("Fatal error", "error: "+err.Error()).
-			WithShouldEndSession(true)
+// HandleIntentWhen registers the handler for the given intent, used only when the predicate matches.
+//
+// Requests not matching any predicate fall back to the handler registered with HandleIntent.
+func (m *ServeMux) HandleIntentWhen(intent string, when Predicate, handler Handler) {
+	m.HandleIntentWhenPriority(intent, 0, when, handler)
+}
+
+// HandleIntentWhenFunc registers the handler function for the given intent, used only when the predicate matches.
+func (m *ServeMux) HandleIntentWhenFunc(intent string, when Predicate, handler HandlerFunc) {
+	m.HandleIntentWhen(intent, when, handler)
+}
+
+// HandleIntentWhenPriority registers the handler for the given intent with a priority.
+//
+// Predicates are evaluated by descending priority, handlers with equal priority in the order of registration.
+func (m *ServeMux) HandleIntentWhenPriority(intent string, priority int, when Predicate, handler Handler) {
+	if handler == nil {
+		panic("alexa: nil handler")
+	}
+
+	if when == nil {
+		panic("alexa: nil predicate")
+	}
+
+	m.mu.Lock()
+
+	routes := append(m.intentRoutes[intent], route{when: when, priority: priority, handler: handler})
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].priority > routes[j].priority
 	})
+	m.intentRoutes[intent] = routes
+
+	m.mu.Unlock()
+}
+
+// HandleNotFound registers the handler for requests without a matching handler.
+//
+// AMAZON.FallbackIntent is routed to this handler unless it has a handler registered.
+func (m *ServeMux) HandleNotFound(handler Handler) {
+	if handler == nil {
+		panic("alexa: nil handler")
+	}
+
+	m.mu.Lock()
+
+	m.notFound = handler
+
+	m.mu.Unlock()
+}
+
+// HandleNotFoundFunc registers the handler function for requests without a matching handler.
+func (m *ServeMux) HandleNotFoundFunc(handler HandlerFunc) {
+	m.HandleNotFound(handler)
+}
+
+// HandleError registers the handler for errors that occur while serving a request.
+func (m *ServeMux) HandleError(handler ErrorHandler) {
+	if handler == nil {
+		panic("alexa: nil handler")
+	}
+
+	m.mu.Lock()
+
+	m.errHandler = handler
+
+	m.mu.Unlock()
+}
+
+// HandleErrorFunc registers the handler function for errors that occur while serving a request.
+func (m *ServeMux) HandleErrorFunc(handler ErrorHandlerFunc) {
+	m.HandleError(handler)
+}
+
+func (m *ServeMux) notFoundHandler() Handler {
+	m.mu.RLock()
+	h := m.notFound
+	m.mu.RUnlock()
+
+	if h == nil {
+		return DefaultNotFoundHandler(m.LocaleRegistry())
+	}
+
+	return h
+}
+
+func (m *ServeMux) errorHandler() ErrorHandler {
+	m.mu.RLock()
+	h := m.errHandler
+	m.mu.RUnlock()
+
+	if h == nil {
+		return DefaultErrorHandler(m.LocaleRegistry())
+	}
+
+	return h
+}
+
+// DefaultNotFoundHandler returns a handler responding with the localized "not found" error.
+//
+// The session is kept open for AMAZON.FallbackIntent, so the user can try again.
+func DefaultNotFoundHandler(registry l10n.LocaleRegistry) Handler {
+	return HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {
+		loc, resp := requestLocale(registry, r)
+		if loc == nil {
+			b.With(resp)
+			return
+		}
+
+		b.With(Response{
+			Title:  getAnyOr(loc, l10n.KeyErrorNotFoundTitle, defaultNotFoundTitle),
+			Text:   getAnyOr(loc, l10n.KeyErrorNotFoundText, defaultNotFoundText),
+			Speech: getAnyOr(loc, l10n.KeyErrorNotFoundSSML, defaultNotFoundText),
+			End:    r.IntentName() != FallbackIntent,
+		})
+	})
+}
+
+// DefaultErrorHandler returns an error handler responding with the localized error.
+//
+// Locale and ResponseErrors are handled by HandleError, any other error with the standard error keys.
+func DefaultErrorHandler(registry l10n.LocaleRegistry) ErrorHandler {
+	return ErrorHandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope, err error) {
+		loc, resp := requestLocale(registry, r)
+		if loc == nil {
+			b.With(resp)
+			return
+		}
+
+		if HandleError(b, loc, err) {
+			return
+		}
+
+		b.With(Response{
+			Title:  getAnyOr(loc, l10n.KeyErrorTitle, defaultErrorTitle),
+			Text:   getAnyOr(loc, l10n.KeyErrorText, defaultErrorText),
+			Speech: getAnyOr(loc, l10n.KeyErrorSSML, defaultErrorText),
+			End:    true,
+		})
+	})
+}
+
+// Built-in responses of the default handlers for locales without the error translations.
+const (
+	defaultNotFoundTitle = "Not found"
+	defaultNotFoundText  = "Sorry, I can't help with that."
+	defaultErrorTitle    = "Error"
+	defaultErrorText     = "Sorry, something went wrong."
+)
+
+// getAnyOr returns a random translation of the key, or the fallback if the locale has none.
+func getAnyOr(loc l10n.LocaleInstance, key, fallback string) string {
+	if t := loc.GetAny(key); t != "" {
+		return t
+	}
+
+	return fallback
 }
 
 // Serve serves the matched handler.
+//
+// The locale of the request is resolved from the locale registry once and is available to handlers
+// through RequestEnvelope.Locale. It is scoped to the request, so its errors only reflect the current request,
+// and looks up missing keys in the fallbacks of the registry, see l10n.ScopeIn.
 func (m *ServeMux) Serve(b *ResponseBuilder, r *RequestEnvelope) {
 	json, _ := jsoniter.Marshal(r)
 	m.logger.Debug("request", lctx.Str("json", string(json)))
 
+	if loc, _ := GetLocaleWithFallback(m.LocaleRegistry(), r.RequestLocale()); loc != nil {
+		loc = l10n.ScopeIn(m.LocaleRegistry(), loc)
+		loc.ResetErrors()
+		r.locale = loc
+	}
+
 	h, err := m.Handler(r)
 	if err != nil {
-		h = fallbackHandler(err)
+		m.logger.Debug("no handler found", lctx.Error("error", err))
+
+		h = m.notFoundHandler()
 	}
 
 	h.Serve(b, r)
+
+	if err := b.Err(); err != nil {
+		m.logger.Debug("handler failed", lctx.Error("error", err))
+
+		*b = ResponseBuilder{}
+		m.errorHandler().ServeError(b, r, err)
+	}
+
 	json, _ = jsoniter.Marshal(b.Build())
 	m.logger.Debug("response", lctx.Str("json", string(json)))
 }
@@ -224,23 +450,18 @@ func (m *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var h Handler
+	defer func() { _ = r.Body.Close() }()
+
+	builder := &ResponseBuilder{}
 
 	req, err := parseRequest(r.Body)
 	if err != nil {
-		h = fallbackHandler(err)
+		m.logger.Debug("failed to parse request", lctx.Error("error", err))
+		m.errorHandler().ServeError(builder, &RequestEnvelope{}, err)
 	} else {
-		h, err = m.Handler(req)
-		if err != nil {
-			h = fallbackHandler(err)
-		}
+		m.Serve(builder, req)
 	}
 
-	defer func() { _ = r.Body.Close() }()
-
-	builder := &ResponseBuilder{}
-	h.Serve(builder, req)
-
 	resp, err := jsoniter.Marshal(builder.Build())
 	if err != nil {
 		m.logger.Error("failed to marshal response", lctx.Error("error", err))
@@ -254,6 +475,15 @@ func (m *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// requestLocale returns the locale resolved for the request or resolves it from the registry.
+func requestLocale(registry l10n.LocaleRegistry, r *RequestEnvelope) (l10n.LocaleInstance, Response) {
+	if loc := r.Locale(); loc != nil {
+		return loc, Response{}
+	}
+
+	return GetLocaleWithFallback(registry, r.RequestLocale())
+}
+
 func parseRequest(b io.Reader) (*RequestEnvelope, error) {
 	payload, err := io.ReadAll(b)
 	if err != nil {
@@ -294,3 +524,13 @@ func HandleIntent(intent string, handler Handler) {
 func HandleIntentFunc(intent string, handler HandlerFunc) {
 	DefaultServerMux.HandleIntentFunc(intent, handler)
 }
+
+// HandleIntentWhen registers the handler for the given intent and predicate on the DefaultServeMux.
+func HandleIntentWhen(intent string, when Predicate, handler Handler) {
+	DefaultServerMux.HandleIntentWhen(intent, when, handler)
+}
+
+// HandleIntentWhenFunc registers the handler function for the given intent and predicate on the DefaultServeMux.
+func HandleIntentWhenFunc(intent string, when Predicate, handler HandlerFunc) {
+	DefaultServerMux.HandleIntentWhenFunc(intent, when, handler)
+}
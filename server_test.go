@@ -3,6 +3,8 @@ package alexa
 import (
 	"bytes"
 	ctx "context"
+	"errors"
+	"github.com/drpsychick/go-alexa-lambda/l10n"
 	log "github.com/hamba/logger/v2"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +17,29 @@ import (
 	"testing"
 )
 
+func errorRegistry() l10n.LocaleRegistry {
+	reg := l10n.NewRegistry()
+	_ = reg.Register(&l10n.Locale{Name: "en-US", TextSnippets: l10n.Snippets{
+		l10n.KeyErrorTitle:         {"Error"},
+		l10n.KeyErrorText:          {"Something went wrong."},
+		l10n.KeyErrorSSML:          {"<speak>Something went wrong.</speak>"},
+		l10n.KeyErrorNotFoundTitle: {"Not found"},
+		l10n.KeyErrorNotFoundText:  {"I don't know that."},
+		l10n.KeyErrorNotFoundSSML:  {"<speak>I don't know that.</speak>"},
+
+		l10n.KeyErrorNoTranslationTitle: {"Missing translation"},
+		l10n.KeyErrorNoTranslationText:  {"Translation for %s is missing."},
+		l10n.KeyErrorNoTranslationSSML:  {"<speak>Translation for %s is missing.</speak>"},
+	}})
+	_ = reg.Register(&l10n.Locale{Name: "de-DE", TextSnippets: l10n.Snippets{
+		l10n.KeyErrorNotFoundTitle: {"Nicht gefunden"},
+		l10n.KeyErrorNotFoundText:  {"Das kenne ich nicht."},
+		l10n.KeyErrorNotFoundSSML:  {"<speak>Das kenne ich nicht.</speak>"},
+	}})
+
+	return reg
+}
+
 func TestServer(t *testing.T) {
 	s := Server{
 		Handler: HandlerFunc(
@@ -101,6 +126,7 @@ func TestMuxServeHTTPProbes(t *testing.T) {
 
 func TestMuxServeHTTP(t *testing.T) {
 	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(errorRegistry())
 	rw := httptest.NewRecorder()
 	b := io.NopCloser(bytes.NewReader([]byte(`{}`)))
 	r := &http.Request{Method: http.MethodGet, Body: b}
@@ -112,7 +138,7 @@ func TestMuxServeHTTP(t *testing.T) {
 	err := jsoniter.Unmarshal(res, resp)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rw.Result().StatusCode)
-	assert.Contains(t, string(res), "error")
+	assert.Equal(t, "Not found", resp.Response.Card.Title)
 
 	rw = httptest.NewRecorder()
 	b = io.NopCloser(bytes.NewReader([]byte(`foo`)))
@@ -125,10 +151,12 @@ func TestMuxServeHTTP(t *testing.T) {
 	err = jsoniter.Unmarshal(res, resp)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rw.Result().StatusCode)
-	assert.Contains(t, string(res), "error")
+	assert.Equal(t, "Error", resp.Response.Card.Title)
+	assert.Equal(t, "Something went wrong.", resp.Response.Card.Content)
+	assert.NotContains(t, string(res), "foo")
 
 	rw = httptest.NewRecorder()
-	req := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Intent: Intent{Name: HelpIntent}}}
+	req := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Locale: LocaleGerman, Intent: Intent{Name: HelpIntent}}}
 	content, err := jsoniter.Marshal(req)
 	assert.NoError(t, err)
 	b = io.NopCloser(bytes.NewReader(content))
@@ -141,7 +169,9 @@ func TestMuxServeHTTP(t *testing.T) {
 	err = jsoniter.Unmarshal(res, resp)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rw.Result().StatusCode)
-	assert.Contains(t, string(res), "error")
+	assert.Equal(t, "Nicht gefunden", resp.Response.Card.Title)
+	assert.Equal(t, "<speak>Das kenne ich nicht.</speak>", resp.Response.OutputSpeech.SSML)
+	assert.True(t, resp.Response.ShouldEndSession)
 }
 
 func TestHandler(t *testing.T) {
@@ -216,12 +246,239 @@ func TestHandleIntentFunc(t *testing.T) {
 	assert.Equal(t, funcName1, funcName2)
 }
 
+func TestHandleIntentWhen(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	h := HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) { b.WithSimpleCard("default", "") })
+	hc := HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) { b.WithSimpleCard("completed", "") })
+	hs := HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) { b.WithSimpleCard("slot", "") })
+
+	mux.HandleIntent("Intent", h)
+	mux.HandleIntentWhenFunc("Intent", DialogState(DialogStateCompleted), hc)
+	mux.HandleIntentWhenPriority("Intent", 10, HasSlot("color"), hs)
+
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Intent: Intent{Name: "Intent"}}}
+	b := &ResponseBuilder{}
+	mux.Serve(b, r)
+	assert.Equal(t, "default", b.card.Title)
+
+	r.Request.DialogState = DialogStateCompleted
+	b = &ResponseBuilder{}
+	mux.Serve(b, r)
+	assert.Equal(t, "completed", b.card.Title)
+
+	r.Request.Intent.Slots = map[string]*Slot{"color": {Name: "color", Value: "red"}}
+	b = &ResponseBuilder{}
+	mux.Serve(b, r)
+	assert.Equal(t, "slot", b.card.Title)
+}
+
+func TestHandleIntentWhen_NoFallback(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	h := HandlerFunc(func(b *ResponseBuilder, r *RequestEnvelope) {})
+
+	mux.HandleIntentWhen("Intent", DialogState(DialogStateCompleted), h)
+
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Intent: Intent{Name: "Intent"}}}
+	_, err := mux.Handler(r)
+	assert.Error(t, err)
+
+	r.Request.DialogState = DialogStateCompleted
+	_, err = mux.Handler(r)
+	assert.NoError(t, err)
+
+	assert.Panics(t, func() { mux.HandleIntentWhen("Intent", nil, h) })
+	assert.Panics(t, func() { mux.HandleIntentWhen("Intent", DialogState(DialogStateStarted), nil) })
+}
+
 func TestServe(t *testing.T) {
 	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(errorRegistry())
 	r := &RequestEnvelope{}
 	b := &ResponseBuilder{}
 
 	mux.Serve(b, r)
 
-	assert.Equal(t, "Fatal error", b.card.Title)
+	assert.Equal(t, "Not found", b.card.Title)
+}
+
+func TestServe_FallbackIntent(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(errorRegistry())
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Locale: LocaleAmericanEnglish, Intent: Intent{Name: FallbackIntent}}}
+	b := &ResponseBuilder{}
+
+	mux.Serve(b, r)
+
+	assert.Equal(t, "Not found", b.card.Title)
+	assert.False(t, b.shouldEndSession)
+
+	mux.HandleNotFoundFunc(func(b *ResponseBuilder, r *RequestEnvelope) { b.WithSimpleCard("custom", r.IntentName()) })
+	b = &ResponseBuilder{}
+
+	mux.Serve(b, r)
+
+	assert.Equal(t, "custom", b.card.Title)
+	assert.Equal(t, FallbackIntent, b.card.Content)
+}
+
+func TestServe_NoLocale(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(l10n.NewRegistry())
+	b := &ResponseBuilder{}
+
+	mux.Serve(b, &RequestEnvelope{})
+
+	assert.Equal(t, "Error", b.card.Title)
+	assert.True(t, b.shouldEndSession)
+}
+
+func TestMuxHandleError(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.HandleErrorFunc(func(b *ResponseBuilder, r *RequestEnvelope, err error) {
+		b.WithSimpleCard("custom", err.Error())
+	})
+	rw := httptest.NewRecorder()
+	r := &http.Request{Method: http.MethodGet, Body: io.NopCloser(bytes.NewReader([]byte(`foo`)))}
+
+	mux.ServeHTTP(rw, r)
+
+	res, _ := io.ReadAll(rw.Result().Body)
+	assert.Contains(t, string(res), "custom")
+
+	assert.Panics(t, func() { mux.HandleError(nil) })
+	assert.Panics(t, func() { mux.HandleNotFound(nil) })
+}
+
+func TestServe_ResponseError(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(errorRegistry())
+	mux.HandleIntentFunc("Intent", func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithLocalized(r.Locale(), "Missing").WithSessionAttributes(map[string]interface{}{"foo": "bar"})
+	})
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Locale: LocaleAmericanEnglish, Intent: Intent{Name: "Intent"}}}
+	b := &ResponseBuilder{}
+
+	mux.Serve(b, r)
+
+	assert.NoError(t, b.Err())
+	assert.Nil(t, b.sessionAttr)
+	assert.True(t, b.shouldEndSession)
+}
+
+func TestServe_RequestLocale(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	reg := errorRegistry()
+	mux.SetLocaleRegistry(reg)
+	en, _ := reg.Resolve("en-US")
+	en.Get("missing")
+
+	var got l10n.LocaleInstance
+	var errs []error
+	mux.HandleIntentFunc("Intent", func(b *ResponseBuilder, r *RequestEnvelope) {
+		got = r.Locale()
+		errs = r.Locale().GetErrors()
+	})
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Locale: LocaleIndianEnglish, Intent: Intent{Name: "Intent"}}}
+
+	mux.Serve(&ResponseBuilder{}, r)
+
+	assert.Equal(t, "en-US", got.GetName())
+	assert.Empty(t, errs)
+	assert.Len(t, en.GetErrors(), 1)
+
+	r.Request.Locale = LocaleFrench
+	mux.Serve(&ResponseBuilder{}, r)
+
+	assert.Equal(t, "en-US", got.GetName())
+
+	r.Request.Locale = "de-AT"
+	mux.Serve(&ResponseBuilder{}, r)
+
+	assert.Equal(t, "de-DE", got.GetName())
+}
+
+func TestMuxServeHTTP_Concurrent(t *testing.T) {
+	mux := NewServerMux(log.New(nil, log.ConsoleFormat(), log.Info))
+	mux.SetLocaleRegistry(errorRegistry())
+	mux.HandleIntentFunc("Intent", func(b *ResponseBuilder, r *RequestEnvelope) {
+		b.WithLocalized(r.Locale(), r.SlotValue("key"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	send := func(key string) (*ResponseEnvelope, error) {
+		req := &RequestEnvelope{Request: &Request{
+			Type:   TypeIntentRequest,
+			Locale: LocaleAmericanEnglish,
+			Intent: Intent{Name: "Intent", Slots: map[string]*Slot{"key": {Name: "key", Value: key}}},
+		}}
+		content, err := jsoniter.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+		res, err := http.Post(srv.URL, "application/json", bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		resp := &ResponseEnvelope{}
+		return resp, jsoniter.NewDecoder(res.Body).Decode(resp)
+	}
+
+	done := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		go func(i int) {
+			key, want := "Error_NotFound", "Not found"
+			if i%2 == 0 {
+				key, want = "Missing", "Missing translation"
+			}
+			resp, err := send(key)
+			if err == nil && resp.Response.Card.Title != want {
+				err = errors.New("unexpected title " + resp.Response.Card.Title + " for key " + key)
+			}
+			done <- err
+		}(i)
+	}
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, <-done)
+	}
+}
+
+func TestDefaultErrorHandler(t *testing.T) {
+	reg := errorRegistry()
+	r := &RequestEnvelope{Request: &Request{Locale: LocaleAmericanEnglish}}
+
+	b := &ResponseBuilder{}
+	DefaultErrorHandler(reg).ServeError(b, r, errors.New("internal details"))
+	assert.Equal(t, "Error", b.card.Title)
+	assert.Equal(t, "Something went wrong.", b.card.Content)
+
+	b = &ResponseBuilder{}
+	DefaultErrorHandler(reg).ServeError(b, r, TextError{"en-US", "text error"})
+	assert.Equal(t, "text error", b.card.Content)
+
+	b = &ResponseBuilder{}
+	DefaultErrorHandler(l10n.NewRegistry()).ServeError(b, r, errors.New("foo"))
+	assert.Equal(t, "Error", b.card.Title)
+}
+
+func TestDefaultHandlers_WithoutTranslations(t *testing.T) {
+	reg := l10n.NewRegistry()
+	assert.NoError(t, reg.Register(l10n.NewLocale("en-US"), l10n.AsDefault()))
+	r := &RequestEnvelope{Request: &Request{Type: TypeIntentRequest, Locale: LocaleAmericanEnglish}}
+
+	b := &ResponseBuilder{}
+	DefaultNotFoundHandler(reg).Serve(b, r)
+	resp := b.Build()
+	assert.Equal(t, "Not found", resp.Response.Card.Title)
+	assert.NotEmpty(t, resp.Response.Card.Content)
+	assert.NotEmpty(t, resp.Response.OutputSpeech.Text)
+
+	b = &ResponseBuilder{}
+	DefaultErrorHandler(reg).ServeError(b, r, errors.New("foo"))
+	resp = b.Build()
+	assert.Equal(t, "Error", resp.Response.Card.Title)
+	assert.NotEmpty(t, resp.Response.Card.Content)
+	assert.NotEmpty(t, resp.Response.OutputSpeech.Text)
+	assert.True(t, resp.Response.ShouldEndSession)
 }
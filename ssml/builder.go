@@ -0,0 +1,394 @@
+package ssml
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// Escape escapes text or an attribute value for SSML.
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// AttributeError defines an invalid attribute value of an SSML element.
+type AttributeError struct {
+	Element   string
+	Attribute string
+	Value     string
+}
+
+// Error returns a string of the error.
+func (e AttributeError) Error() string {
+	return fmt.Sprintf("ssml: invalid value '%s' of attribute '%s' in <%s>", e.Value, e.Attribute, e.Element)
+}
+
+// node is a part of an SSML document.
+type node interface {
+	render(sb *strings.Builder)
+}
+
+type textNode string
+
+func (t textNode) render(sb *strings.Builder) {
+	sb.WriteString(Escape(string(t)))
+}
+
+type attribute struct {
+	name, value string
+}
+
+type element struct {
+	name     string
+	attrs    []attribute
+	children []node
+	empty    bool
+}
+
+func (e *element) render(sb *strings.Builder) {
+	sb.WriteString("<" + e.name)
+
+	for _, a := range e.attrs {
+		sb.WriteString(" " + a.name + `="` + Escape(a.value) + `"`)
+	}
+
+	if e.empty {
+		sb.WriteString("/>")
+		return
+	}
+
+	sb.WriteString(">")
+
+	for _, c := range e.children {
+		c.render(sb)
+	}
+
+	sb.WriteString("</" + e.name + ">")
+}
+
+// Builder builds an SSML document, escaping text and validating attribute values.
+//
+// Elements are appended in order, content of elements is given as Builder:
+//
+//	speech, err := ssml.New().
+//		S(ssml.Text("Welcome to Tom & Jerry's!")).
+//		Break("", "500ms").
+//		Emphasis(ssml.EmphasisLevelStrong, ssml.Text("Let's go")).
+//		Build()
+type Builder struct {
+	nodes []node
+	errs  []error
+}
+
+// New returns an empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Text returns a Builder with the text.
+func Text(text string) *Builder {
+	return New().Text(text)
+}
+
+// Text appends escaped text.
+func (b *Builder) Text(text string) *Builder {
+	b.nodes = append(b.nodes, textNode(text))
+	return b
+}
+
+// Append appends the content of the other builders.
+func (b *Builder) Append(content ...*Builder) *Builder {
+	for _, c := range content {
+		if c == nil {
+			continue
+		}
+
+		b.nodes = append(b.nodes, c.nodes...)
+		b.errs = append(b.errs, c.errs...)
+	}
+
+	return b
+}
+
+// Err returns the errors of invalid attribute values.
+func (b *Builder) Err() error {
+	return errors.Join(b.errs...)
+}
+
+// Build returns the document in <speak> tags or the errors of invalid attribute values.
+func (b *Builder) Build() (string, error) {
+	if err := b.Err(); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// String returns the document in <speak> tags.
+func (b *Builder) String() string {
+	return "<speak>" + b.Inner() + "</speak>"
+}
+
+// Inner returns the document without <speak> tags.
+func (b *Builder) Inner() string {
+	var sb strings.Builder
+	for _, n := range b.nodes {
+		n.render(&sb)
+	}
+
+	return sb.String()
+}
+
+func (b *Builder) element(name string, attrs []attribute, content *Builder) *Builder {
+	e := &element{name: name, attrs: attrs, empty: content == nil}
+	if content != nil {
+		// copy the nodes, the content can be appended to after it is used
+		e.children = append([]node(nil), content.nodes...)
+		b.errs = append(b.errs, content.errs...)
+	}
+
+	b.nodes = append(b.nodes, e)
+
+	return b
+}
+
+func (b *Builder) invalid(elem, attr, value string) {
+	b.errs = append(b.errs, AttributeError{elem, attr, value})
+}
+
+// check records an error if the value is not valid.
+func (b *Builder) check(elem, attr, value string, valid bool) {
+	if !valid {
+		b.invalid(elem, attr, value)
+	}
+}
+
+// P appends a paragraph.
+func (b *Builder) P(content *Builder) *Builder {
+	return b.element("p", nil, orEmpty(content))
+}
+
+// S appends a sentence.
+func (b *Builder) S(content *Builder) *Builder {
+	return b.element("s", nil, orEmpty(content))
+}
+
+var breakTime = regexp.MustCompile(`^(\d+(\.\d+)?)(ms|s)$`)
+
+// Break appends a break of the given strength or time (e.g. "500ms", max "10s"), the time if both are given.
+func (b *Builder) Break(strength BreakStrength, time string) *Builder {
+	switch {
+	case time != "":
+		b.check("break", "time", time, validBreakTime(time))
+		return b.element("break", []attribute{{"time", time}}, nil)
+	case strength != "":
+		b.check("break", "strength", string(strength), oneOf(string(strength),
+			BreakStrengthNone, BreakStrengthXWeak, BreakStrengthWeak,
+			BreakStrengthMedium, BreakStrengthStrong, BreakStrengthXStrong))
+
+		return b.element("break", []attribute{{"strength", string(strength)}}, nil)
+	default:
+		return b.element("break", nil, nil)
+	}
+}
+
+func validBreakTime(time string) bool {
+	m := breakTime.FindStringSubmatch(time)
+	if m == nil {
+		return false
+	}
+
+	v, _ := strconv.ParseFloat(m[1], 64)
+	if m[3] == "s" {
+		v *= 1000
+	}
+
+	return v <= 10000
+}
+
+// Emphasis appends content with emphasis, the level is optional.
+func (b *Builder) Emphasis(level EmphasisLevel, content *Builder) *Builder {
+	var attrs []attribute
+
+	if level != "" {
+		b.check("emphasis", "level", string(level),
+			oneOf(string(level), EmphasisLevelStrong, EmphasisLevelModerate, EmphasisLevelReduced))
+
+		attrs = append(attrs, attribute{"level", string(level)})
+	}
+
+	return b.element("emphasis", attrs, orEmpty(content))
+}
+
+var (
+	percent       = regexp.MustCompile(`^\d+(\.\d+)?%$`)
+	signedPercent = regexp.MustCompile(`^[+-]\d+(\.\d+)?%$`)
+	decibel       = regexp.MustCompile(`^[+-]\d+(\.\d+)?dB$`)
+)
+
+// Prosody appends content with modified rate, pitch and volume, empty values are omitted.
+func (b *Builder) Prosody(rate ProsodyRate, pitch ProsodyPitch, volume ProsodyVolume, content *Builder) *Builder {
+	var attrs []attribute
+
+	if rate != "" {
+		b.check("prosody", "rate", string(rate), percent.MatchString(string(rate)) || oneOf(string(rate),
+			ProsodyRateXSlow, ProsodyRateSlow, ProsodyRateMedium, ProsodyRateFast, ProsodyRateXFast))
+
+		attrs = append(attrs, attribute{"rate", string(rate)})
+	}
+
+	if pitch != "" {
+		b.check("prosody", "pitch", string(pitch), signedPercent.MatchString(string(pitch)) || oneOf(string(pitch),
+			ProsodyPitchXLow, ProsodyPitchLow, ProsodyPitchMedium, ProsodyPitchHigh, ProsodyPitchXHigh))
+
+		attrs = append(attrs, attribute{"pitch", string(pitch)})
+	}
+
+	if volume != "" {
+		b.check("prosody", "volume", string(volume), decibel.MatchString(string(volume)) || oneOf(string(volume),
+			ProsodyVolumeSilent, ProsodyVolumeXSoft, ProsodyVolumeSoft,
+			ProsodyVolumeMedium, ProsodyVolumeLoud, ProsodyVolumeXLoud))
+
+		attrs = append(attrs, attribute{"volume", string(volume)})
+	}
+
+	return b.element("prosody", attrs, orEmpty(content))
+}
+
+// SayAsInterpretAsValues are the supported values of the interpret-as attribute.
+var SayAsInterpretAsValues = []SayAsInterpretAs{
+	SayAsInterpretAsCharacters, SayAsInterpretAsSpellOut, SayAsInterpretAsCardinal, SayAsInterpretAsNumber,
+	SayAsInterpretAsOrdinal, SayAsInterpretAsDigits, SayAsInterpretAsFraction, SayAsInterpretAsUnit,
+	SayAsInterpretAsDate, SayAsInterpretAsTime, SayAsInterpretAsTelephone, SayAsInterpretAsAddress,
+	SayAsInterpretAsInterjection, SayAsInterpretAsExpletive,
+}
+
+// SayAsFormats are the formats of SayAsInterpretAsDate.
+var SayAsFormats = []string{"mdy", "dmy", "ymd", "md", "dm", "ym", "my", "d", "m", "y"}
+
+// SayAs appends text interpreted in a specific way, the format is only used for dates.
+func (b *Builder) SayAs(interpretAs SayAsInterpretAs, format, text string) *Builder {
+	b.check("say-as", "interpret-as", string(interpretAs), oneOf(string(interpretAs),
+		SayAsInterpretAsValues...))
+
+	attrs := []attribute{{"interpret-as", string(interpretAs)}}
+
+	if interpretAs == SayAsInterpretAsDate && format != "" {
+		b.check("say-as", "format", format, oneOf(format, SayAsFormats...))
+
+		attrs = append(attrs, attribute{"format", format})
+	}
+
+	return b.element("say-as", attrs, Text(text))
+}
+
+// Sub appends text pronounced as the alias.
+func (b *Builder) Sub(alias, text string) *Builder {
+	b.check("sub", "alias", alias, alias != "")
+
+	return b.element("sub", []attribute{{"alias", alias}}, Text(text))
+}
+
+// Phoneme appends text pronounced by the phonetic characters of the alphabet.
+func (b *Builder) Phoneme(alphabet PhonemeAlphabet, ph, text string) *Builder {
+	b.check("phoneme", "alphabet", string(alphabet), oneOf(string(alphabet), PhonemeAlphabetIPA, PhonemeAlphabetXSampa))
+	b.check("phoneme", "ph", ph, ph != "")
+
+	return b.element("phoneme", []attribute{{"alphabet", string(alphabet)}, {"ph", ph}}, Text(text))
+}
+
+var language = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`)
+
+// Lang appends content spoken in the language, e.g. "de-DE".
+func (b *Builder) Lang(lang string, content *Builder) *Builder {
+	b.check("lang", "xml:lang", lang, language.MatchString(lang))
+
+	return b.element("lang", []attribute{{"xml:lang", lang}}, orEmpty(content))
+}
+
+// Voice appends content spoken by the voice.
+//
+// Like Validate, voices not in the catalog are accepted, use VoiceChecked to only accept voices of the catalog.
+func (b *Builder) Voice(voice PollyVoice, content *Builder) *Builder {
+	b.check("voice", "name", string(voice), voice != "")
+
+	return b.element("voice", []attribute{{"name", string(voice)}}, orEmpty(content))
+}
+
+// VoiceChecked appends content spoken by the voice of the catalog or records a VoiceError,
+// see VoicesFor and RegisterVoice.
+func (b *Builder) VoiceChecked(voice PollyVoice, content *Builder) *Builder {
+	if _, ok := VoiceInfo(voice); !ok && voice != "" {
+		b.errs = append(b.errs, VoiceError{Voice: voice})
+	}
+
+	return b.Voice(voice, content)
+}
+
+// Audio appends an audio file of an https or soundbank URL.
+func (b *Builder) Audio(src string) *Builder {
+	b.check("audio", "src", src, strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "soundbank://"))
+
+	return b.element("audio", []attribute{{"src", src}}, nil)
+}
+
+// Domain appends content spoken in the domain.
+func (b *Builder) Domain(domain AmazonDomain, content *Builder) *Builder {
+	b.check("amazon:domain", "name", string(domain), oneOf(string(domain),
+		AmazonDomainConversational, AmazonDomainLong, AmazonDomainMusic, AmazonDomainNews, AmazonDomainFun))
+
+	return b.element("amazon:domain", []attribute{{"name", string(domain)}}, orEmpty(content))
+}
+
+// Effect appends content spoken with the effect.
+func (b *Builder) Effect(effect AmazonEffect, content *Builder) *Builder {
+	b.check("amazon:effect", "name", string(effect), oneOf(string(effect), AmazonEffectWhispered))
+
+	return b.element("amazon:effect", []attribute{{"name", string(effect)}}, orEmpty(content))
+}
+
+// Emotion appends content spoken with the emotion and intensity.
+func (b *Builder) Emotion(emotion AmazonEmotion, intensity AmazonEmotionIntensity, content *Builder) *Builder {
+	b.check("amazon:emotion", "name", string(emotion), oneOf(string(emotion), EmotionExcited, EmotionDisappointed))
+	b.check("amazon:emotion", "intensity", string(intensity), oneOf(string(intensity),
+		EmotionIntensityLow, EmotionIntensityMedium, EmotionIntensityHigh))
+
+	attrs := []attribute{{"name", string(emotion)}, {"intensity", string(intensity)}}
+
+	return b.element("amazon:emotion", attrs, orEmpty(content))
+}
+
+// W appends a word pronounced in the role.
+func (b *Builder) W(role AmazonRole, text string) *Builder {
+	b.check("w", "role", string(role), oneOf(string(role), AmazonRoleVB, AmazonRoleVBD, AmazonRoleNN, AmazonRoleSense1))
+
+	return b.element("w", []attribute{{"role", string(role)}}, Text(text))
+}
+
+func orEmpty(content *Builder) *Builder {
+	if content == nil {
+		return New()
+	}
+
+	return content
+}
+
+func oneOf[T ~string](value string, valid ...T) bool {
+	for _, v := range valid {
+		if value == string(v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package ssml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *Builder
+		want string
+	}{
+		{"Empty", New(), `<speak></speak>`},
+		{"Escaped", Text(`Tom & Jerry's <"show">`), `<speak>Tom &amp; Jerry&apos;s &lt;&quot;show&quot;&gt;</speak>`},
+		{"Sentence", New().S(Text("Hello")).S(Text("World")), `<speak><s>Hello</s><s>World</s></speak>`},
+		{"BreakTime", New().Break(BreakStrengthStrong, "500ms"), `<speak><break time="500ms"/></speak>`},
+		{"BreakStrength", New().Break(BreakStrengthStrong, ""), `<speak><break strength="strong"/></speak>`},
+		{"Nested", New().P(Text("Hi ").Emphasis(EmphasisLevelStrong, Text("you"))),
+			`<speak><p>Hi <emphasis level="strong">you</emphasis></p></speak>`},
+		{"Prosody", New().Prosody(ProsodyRate("90%"), ProsodyPitch("+5%"), ProsodyVolume("-2dB"), Text("x")),
+			`<speak><prosody rate="90%" pitch="+5%" volume="-2dB">x</prosody></speak>`},
+		{"SayAs", New().SayAs(SayAsInterpretAsDate, "ymd", "20240301"),
+			`<speak><say-as interpret-as="date" format="ymd">20240301</say-as></speak>`},
+		{"SubEscaped", New().Sub(`A&B`, "AB"), `<speak><sub alias="A&amp;B">AB</sub></speak>`},
+		{"Voice", New().Voice(DEVoiceMarlene, New().Lang("de-DE", Text("Hallo"))),
+			`<speak><voice name="Marlene"><lang xml:lang="de-DE">Hallo</lang></voice></speak>`},
+		{"Audio", New().Audio("soundbank://soundlibrary/animals/amzn_sfx_dog_med_bark_1x_01"),
+			`<speak><audio src="soundbank://soundlibrary/animals/amzn_sfx_dog_med_bark_1x_01"/></speak>`},
+		{"Amazon", New().Domain(AmazonDomainNews, Text("a")).Effect(AmazonEffectWhispered, Text("b")).
+			Emotion(EmotionExcited, EmotionIntensityHigh, Text("c")).W(AmazonRoleVB, "read"),
+			`<speak><amazon:domain name="news">a</amazon:domain><amazon:effect name="whispered">b</amazon:effect>` +
+				`<amazon:emotion name="excited" intensity="high">c</amazon:emotion><w role="amazon:VB">read</w></speak>`},
+		{"Append", New().Append(Text("a"), nil, Text("b")), `<speak>ab</speak>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_SayAs(t *testing.T) {
+	tests := []struct {
+		interpretAs SayAsInterpretAs
+		text        string
+	}{
+		{SayAsInterpretAsCharacters, "abc"},
+		{SayAsInterpretAsSpellOut, "abc"},
+		{SayAsInterpretAsCardinal, "12"},
+		{SayAsInterpretAsNumber, "12"},
+		{SayAsInterpretAsOrdinal, "12"},
+		{SayAsInterpretAsDigits, "12"},
+		{SayAsInterpretAsFraction, "3/20"},
+		{SayAsInterpretAsUnit, "10km"},
+		{SayAsInterpretAsDate, "20240301"},
+		{SayAsInterpretAsTime, `1'21"`},
+		{SayAsInterpretAsTelephone, "2025551212"},
+		{SayAsInterpretAsAddress, "Main St"},
+		{SayAsInterpretAsInterjection, "bingo"},
+		{SayAsInterpretAsExpletive, "darn"},
+	}
+	if len(tests) != len(SayAsInterpretAsValues) {
+		t.Fatalf("tests cover %d values, want %d", len(tests), len(SayAsInterpretAsValues))
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.interpretAs), func(t *testing.T) {
+			got, err := New().SayAs(tt.interpretAs, "", tt.text).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			want := "<speak>" + SayAs(tt.interpretAs, "", Escape(tt.text)) + "</speak>"
+			if got != want {
+				t.Errorf("Build() = %v, want %v", got, want)
+			}
+			if err := Validate(got); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *Builder
+		want AttributeError
+	}{
+		{"BreakTime", New().Break("", "11s"), AttributeError{"break", "time", "11s"}},
+		{"BreakStrength", New().Break("loud", ""), AttributeError{"break", "strength", "loud"}},
+		{"Emphasis", New().Emphasis("huge", nil), AttributeError{"emphasis", "level", "huge"}},
+		{"ProsodyRate", New().Prosody("fastest", "", "", nil), AttributeError{"prosody", "rate", "fastest"}},
+		{"SayAs", New().SayAs("spelled", "", "abc"), AttributeError{"say-as", "interpret-as", "spelled"}},
+		{"SayAsFormat", New().SayAs(SayAsInterpretAsDate, "yyyy", "2024"), AttributeError{"say-as", "format", "yyyy"}},
+		{"Lang", New().Lang("german", nil), AttributeError{"lang", "xml:lang", "german"}},
+		{"Audio", New().Audio("http://example.com/a.mp3"), AttributeError{"audio", "src", "http://example.com/a.mp3"}},
+		{"Nested", New().S(New().Break("", "1h")), AttributeError{"break", "time", "1h"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.Build()
+
+			var attrErr AttributeError
+			if !errors.As(err, &attrErr) {
+				t.Fatalf("Build() error = %v, want %v", err, tt.want)
+			}
+			if attrErr != tt.want {
+				t.Errorf("Build() error = %v, want %v", attrErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_ContentReuse(t *testing.T) {
+	content := New().Text("a").Text("b")
+
+	b := New().S(content)
+	content.Text("c")
+	b.P(content)
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if want := `<speak><s>ab</s><p>abc</p></speak>`; got != want {
+		t.Errorf("Build() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package ssml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSpeechCharacters is the maximum number of characters of output speech accepted by Alexa.
+const MaxSpeechCharacters = 8000
+
+// CharactersPerSecond are the approximate speaking rates by language, "" is the default.
+var CharactersPerSecond = map[string]float64{
+	"":   15,
+	"de": 14,
+	"ja": 8,
+}
+
+// prosodyRates are the rates relative to medium.
+var prosodyRates = map[ProsodyRate]float64{
+	ProsodyRateXSlow:  0.5,
+	ProsodyRateSlow:   0.75,
+	ProsodyRateMedium: 1,
+	ProsodyRateFast:   1.25,
+	ProsodyRateXFast:  1.5,
+}
+
+// breakStrengths are the durations of breaks by strength.
+var breakStrengths = map[BreakStrength]time.Duration{
+	BreakStrengthNone:    0,
+	BreakStrengthXWeak:   100 * time.Millisecond,
+	BreakStrengthWeak:    250 * time.Millisecond,
+	BreakStrengthMedium:  400 * time.Millisecond,
+	BreakStrengthStrong:  700 * time.Millisecond,
+	BreakStrengthXStrong: time.Second,
+}
+
+// Estimate is the estimated length of speech.
+type Estimate struct {
+	// Characters is the number of characters of the text, as counted by Alexa against MaxSpeechCharacters.
+	Characters int
+	// Speech is the approximate duration of the spoken text, considering the prosody rate.
+	Speech time.Duration
+	// Breaks is the duration of breaks.
+	Breaks time.Duration
+	// Audio is the duration of audio known to the lookup given with WithAudioDuration.
+	Audio time.Duration
+}
+
+// Duration returns the total duration of the speech.
+func (e Estimate) Duration() time.Duration {
+	return e.Speech + e.Breaks + e.Audio
+}
+
+// EstimateSpeech parses SSML speech and estimates its length, see Node.Estimate.
+func EstimateSpeech(speech string, opts ...ValidateFunc) (Estimate, error) {
+	n, err := Parse(speech)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return n.Estimate(opts...), nil
+}
+
+// Estimate estimates the length of the speech for the locale given with ForLocale
+// and the audio durations given with WithAudioDuration.
+func (n *Node) Estimate(opts ...ValidateFunc) Estimate {
+	var cfg ValidateConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	lang, _, _ := strings.Cut(cfg.Locale, "-")
+
+	cps, ok := CharactersPerSecond[lang]
+	if !ok {
+		cps = CharactersPerSecond[""]
+	}
+
+	e := &estimator{cfg: cfg}
+	e.node(n, 1)
+
+	est := e.est
+	est.Speech = time.Duration(e.spoken / cps * float64(time.Second))
+
+	return est
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+type estimator struct {
+	cfg ValidateConfig
+	est Estimate
+	// spoken are the spoken characters weighted by the prosody rate.
+	spoken float64
+}
+
+func (e *estimator) node(n *Node, rate float64) {
+	if n.IsText() {
+		chars := utf8.RuneCountInString(whitespace.ReplaceAllString(n.Text, " "))
+		e.est.Characters += chars
+		e.spoken += float64(chars) / rate
+
+		return
+	}
+
+	switch n.Name {
+	case "sub":
+		e.est.Characters += utf8.RuneCountInString(n.innerText())
+		e.spoken += float64(utf8.RuneCountInString(n.Attr("alias"))) / rate
+
+		return
+	case "break":
+		e.est.Breaks += breakDuration(n)
+		return
+	case "audio":
+		if e.cfg.AudioDuration != nil {
+			if d, ok := e.cfg.AudioDuration(n.Attr("src")); ok {
+				e.est.Audio += d
+			}
+		}
+
+		return
+	case "prosody":
+		rate *= prosodyRate(ProsodyRate(n.Attr("rate")))
+	}
+
+	for _, c := range n.Children {
+		e.node(c, rate)
+	}
+}
+
+// breakDuration returns the duration of the break, medium if neither time nor strength are given.
+func breakDuration(n *Node) time.Duration {
+	if t := n.Attr("time"); t != "" {
+		m := breakTime.FindStringSubmatch(t)
+		if m == nil {
+			return 0
+		}
+
+		v, _ := strconv.ParseFloat(m[1], 64)
+		if m[3] == "s" {
+			v *= 1000
+		}
+
+		return time.Duration(v * float64(time.Millisecond))
+	}
+
+	if d, ok := breakStrengths[BreakStrength(n.Attr("strength"))]; ok {
+		return d
+	}
+
+	return breakStrengths[BreakStrengthMedium]
+}
+
+// prosodyRate returns the rate relative to medium, e.g. 1.5 for "150%".
+func prosodyRate(rate ProsodyRate) float64 {
+	if r, ok := prosodyRates[rate]; ok {
+		return r
+	}
+
+	if p, err := strconv.ParseFloat(strings.TrimSuffix(string(rate), "%"), 64); err == nil && p > 0 {
+		return p / 100
+	}
+
+	return 1
+}
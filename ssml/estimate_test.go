@@ -0,0 +1,62 @@
+package ssml
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateSpeech(t *testing.T) {
+	audio := WithAudioDuration(func(src string) (time.Duration, bool) {
+		return 2 * time.Second, src == string(SoundDoorbell)
+	})
+
+	tests := []struct {
+		name   string
+		speech string
+		opts   []ValidateFunc
+		want   Estimate
+	}{
+		{"Text", "<speak>" + strings.Repeat("a", 30) + "</speak>", nil, Estimate{Characters: 30, Speech: 2 * time.Second}},
+		{"Whitespace", "<speak>Hi   <emphasis>you</emphasis></speak>", nil,
+			Estimate{Characters: 6, Speech: 400 * time.Millisecond}},
+		{"Japanese", "<speak>" + strings.Repeat("あ", 8) + "</speak>", []ValidateFunc{ForLocale("ja-JP")},
+			Estimate{Characters: 8, Speech: time.Second}},
+		{"Prosody", `<speak><prosody rate="50%">` + strings.Repeat("a", 15) + "</prosody></speak>", nil,
+			Estimate{Characters: 15, Speech: 2 * time.Second}},
+		{"NestedProsody", `<speak><prosody rate="x-slow"><prosody rate="200%">` + strings.Repeat("a", 15) +
+			"</prosody></prosody></speak>", nil, Estimate{Characters: 15, Speech: time.Second}},
+		{"Sub", `<speak><sub alias="` + strings.Repeat("a", 15) + `">Al</sub></speak>`, nil,
+			Estimate{Characters: 2, Speech: time.Second}},
+		{"Breaks", `<speak><break time="1.5s"/><break time="500ms"/><break strength="x-strong"/><break/></speak>`, nil,
+			Estimate{Breaks: 3400 * time.Millisecond}},
+		{"Audio", `<speak><audio src="` + string(SoundDoorbell) + `"/><audio src="` + string(SoundDogBark) + `"/></speak>`,
+			[]ValidateFunc{audio}, Estimate{Audio: 2 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EstimateSpeech(tt.speech, tt.opts...)
+			if err != nil {
+				t.Fatalf("EstimateSpeech() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EstimateSpeech() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Length(t *testing.T) {
+	long := "<speak>" + strings.Repeat("a", MaxSpeechCharacters+1) + "</speak>"
+	if err := Validate(long); err == nil || !strings.Contains(err.Error(), "8001 characters") {
+		t.Errorf("Validate() error = %v, want too many characters", err)
+	}
+
+	speech := `<speak>Hello<break time="10s"/></speak>`
+	if err := Validate(speech, WithMaxDuration(5*time.Second)); err == nil {
+		t.Error("Validate() expected duration error")
+	}
+	if err := Validate(speech, WithMaxDuration(15*time.Second)); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
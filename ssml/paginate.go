@@ -0,0 +1,168 @@
+package ssml
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Paginate splits SSML speech into documents of at most budget characters, see Estimate.Characters.
+//
+// Speech is split between paragraphs and sentences first, then between sentences and words of text.
+// Split elements are repeated with their attributes on each page, so tags are never broken.
+// Elements that cannot be split, e.g. say-as, exceed the budget on a page of their own.
+func Paginate(speech string, budget int) ([]string, error) {
+	n, err := Parse(speech)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget <= 0 {
+		return []string{n.String()}, nil
+	}
+
+	var (
+		pages []string
+		page  []*Node
+		size  int
+	)
+
+	for _, u := range split(n, budget).Children {
+		chars := characters(u)
+		if size+chars > budget && len(page) > 0 {
+			pages = append(pages, render(page))
+			page, size = nil, 0
+		}
+
+		page = append(page, u)
+		size += chars
+	}
+
+	if len(page) > 0 || len(pages) == 0 {
+		pages = append(pages, render(page))
+	}
+
+	return pages, nil
+}
+
+func render(nodes []*Node) string {
+	return (&Node{Name: "speak", Children: nodes}).String()
+}
+
+func characters(n *Node) int {
+	return n.Estimate().Characters
+}
+
+// split returns a copy of the node with children of at most budget characters,
+// splitting children into copies of themselves.
+func split(n *Node, budget int) *Node {
+	c := &Node{Name: n.Name, Attrs: n.Attrs}
+
+	for _, child := range n.Children {
+		c.Children = append(c.Children, splitNode(child, budget)...)
+	}
+
+	return c
+}
+
+func splitNode(n *Node, budget int) []*Node {
+	if characters(n) <= budget {
+		return []*Node{n}
+	}
+
+	if n.IsText() {
+		var nodes []*Node
+		for _, t := range splitText(n.Text, budget) {
+			nodes = append(nodes, &Node{Text: t})
+		}
+
+		return nodes
+	}
+
+	if len(allowedChildren[n.Name]) == 0 {
+		return []*Node{n}
+	}
+
+	var (
+		nodes []*Node
+		group *Node
+		size  int
+	)
+
+	for _, child := range split(n, budget).Children {
+		chars := characters(child)
+		if group == nil || size+chars > budget {
+			group = &Node{Name: n.Name, Attrs: n.Attrs}
+			nodes = append(nodes, group)
+			size = 0
+		}
+
+		group.Children = append(group.Children, child)
+		size += chars
+	}
+
+	return nodes
+}
+
+// splitText splits text into parts of at most budget characters between sentences, or words of long sentences.
+func splitText(text string, budget int) []string {
+	var (
+		parts []string
+		part  string
+	)
+
+	for _, s := range splitAfter(text, isSentenceEnd) {
+		if len([]rune(s)) > budget {
+			for _, w := range splitAfter(s, unicode.IsSpace) {
+				part = appendPart(&parts, part, w, budget)
+			}
+
+			continue
+		}
+
+		part = appendPart(&parts, part, s, budget)
+	}
+
+	if strings.TrimSpace(part) != "" {
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+func appendPart(parts *[]string, part, s string, budget int) string {
+	if len([]rune(part+s)) > budget && strings.TrimSpace(part) != "" {
+		*parts = append(*parts, part)
+		return s
+	}
+
+	return part + s
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?'
+}
+
+// splitAfter splits the text after runes matching fn and followed by whitespace, including the whitespace.
+func splitAfter(text string, fn func(r rune) bool) []string {
+	var (
+		parts        []string
+		start        int
+		end, spacing bool
+	)
+
+	for i, r := range text {
+		switch {
+		case fn(r):
+			end, spacing = true, unicode.IsSpace(r)
+		case unicode.IsSpace(r):
+			spacing = end
+		case end && spacing:
+			parts = append(parts, text[start:i])
+			start, end, spacing = i, false, false
+		default:
+			end, spacing = false, false
+		}
+	}
+
+	return append(parts, text[start:])
+}
@@ -0,0 +1,54 @@
+package ssml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name   string
+		speech string
+		budget int
+		want   []string
+	}{
+		{"Fits", "<speak><p>One.</p><p>Two.</p></speak>", 100, []string{"<speak><p>One.</p><p>Two.</p></speak>"}},
+		{"Paragraphs", "<speak><p>One.</p><p>Two.</p><p>Three.</p></speak>", 10,
+			[]string{"<speak><p>One.</p><p>Two.</p></speak>", "<speak><p>Three.</p></speak>"}},
+		{"Sentences", "<speak><p><s>One two.</s><s>Three four.</s></p></speak>", 12,
+			[]string{"<speak><p><s>One two.</s></p></speak>", "<speak><p><s>Three four.</s></p></speak>"}},
+		{"Text", `<speak><prosody rate="slow">One two. Three four. Five.</prosody></speak>`, 12, []string{
+			`<speak><prosody rate="slow">One two. </prosody></speak>`,
+			`<speak><prosody rate="slow">Three four. </prosody></speak>`,
+			`<speak><prosody rate="slow">Five.</prosody></speak>`,
+		}},
+		{"Words", "<speak>One two three four</speak>", 9,
+			[]string{"<speak>One two </speak>", "<speak>three </speak>", "<speak>four</speak>"}},
+		{"Decimal", "<speak>Pi is 3.14 ok</speak>", 11, []string{"<speak>Pi is 3.14 </speak>", "<speak>ok</speak>"}},
+		{"Unsplittable", `<speak>Hi <say-as interpret-as="digits">123456</say-as></speak>`, 4,
+			[]string{"<speak>Hi </speak>", `<speak><say-as interpret-as="digits">123456</say-as></speak>`}},
+		{"NoBudget", "<speak>Hi</speak>", 0, []string{"<speak>Hi</speak>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Paginate(tt.speech, tt.budget)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() = %q, want %q", got, tt.want)
+			}
+			for _, page := range got {
+				if err := Validate(page); err != nil {
+					t.Errorf("Validate(%s) error = %v", page, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginate_Invalid(t *testing.T) {
+	if _, err := Paginate("<speak>Hi", 10); err == nil {
+		t.Error("Paginate() expected error")
+	}
+}
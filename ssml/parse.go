@@ -0,0 +1,412 @@
+package ssml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Attr is an attribute of an SSML element.
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Node is an element or text of a parsed SSML document.
+type Node struct {
+	// Name is the name of the element, e.g. "amazon:domain", empty for text.
+	Name     string
+	Attrs    []Attr
+	Text     string
+	Children []*Node
+}
+
+// Attr returns the value of the attribute.
+func (n *Node) Attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// IsText returns true if the node is text.
+func (n *Node) IsText() bool {
+	return n.Name == ""
+}
+
+// String renders the node as SSML.
+func (n *Node) String() string {
+	var sb strings.Builder
+	n.render(&sb)
+
+	return sb.String()
+}
+
+func (n *Node) render(sb *strings.Builder) {
+	if n.IsText() {
+		sb.WriteString(Escape(n.Text))
+		return
+	}
+
+	e := &element{name: n.Name, empty: len(n.Children) == 0 && emptyElements[n.Name]}
+	for _, a := range n.Attrs {
+		e.attrs = append(e.attrs, attribute{a.Name, a.Value})
+	}
+
+	for _, c := range n.Children {
+		e.children = append(e.children, c)
+	}
+
+	e.render(sb)
+}
+
+// Walk calls fn for the node and all its descendants, depth first.
+func (n *Node) Walk(fn func(n *Node)) {
+	fn(n)
+
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+// Parse parses SSML speech in <speak> tags into a tree.
+func Parse(speech string) (*Node, error) {
+	dec := xml.NewDecoder(strings.NewReader(speech))
+	dec.Strict = true
+
+	var (
+		root  *Node
+		stack []*Node
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("ssml: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Name: xmlName(t.Name)}
+			for _, a := range t.Attr {
+				n.Attrs = append(n.Attrs, Attr{xmlName(a.Name), a.Value})
+			}
+
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			case root != nil:
+				return nil, errors.New("ssml: more than one root element")
+			default:
+				root = n
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) == 0 {
+				if strings.TrimSpace(string(t)) != "" {
+					return nil, errors.New("ssml: text outside of <speak>")
+				}
+
+				continue
+			}
+
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, &Node{Text: string(t)})
+		}
+	}
+
+	if root == nil || root.Name != "speak" {
+		return nil, errors.New("ssml: speech must be in <speak> tags")
+	}
+
+	return root, nil
+}
+
+func xmlName(n xml.Name) string {
+	switch n.Space {
+	case "":
+		return n.Local
+	case "http://www.w3.org/XML/1998/namespace":
+		return "xml:" + n.Local
+	default:
+		return n.Space + ":" + n.Local
+	}
+}
+
+// ValidationError defines SSML that Alexa does not accept.
+type ValidationError struct {
+	Element string
+	Reason  string
+}
+
+// Error returns a string of the error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("ssml: <%s>: %s", e.Element, e.Reason)
+}
+
+// Limits of Alexa for audio in a response.
+const (
+	MaxAudioTags     = 5
+	MaxAudioDuration = 240 * time.Second
+)
+
+// EmotionLocales are the locales supporting amazon:emotion.
+var EmotionLocales = []string{"en-US", "en-AU", "en-CA", "en-GB", "en-IN"}
+
+// ValidateConfig contains the options for Validate.
+type ValidateConfig struct {
+	Locale        string
+	AudioDuration func(src string) (time.Duration, bool)
+	MaxDuration   time.Duration
+	Warn          func(err error)
+}
+
+// ValidateFunc defines the functions to be passed to Validate.
+type ValidateFunc func(cfg *ValidateConfig)
+
+// ForLocale validates the speech for the locale, e.g. tags only supported in some locales.
+func ForLocale(locale string) ValidateFunc {
+	return func(cfg *ValidateConfig) {
+		cfg.Locale = locale
+	}
+}
+
+// WithAudioDuration validates the total duration of audio, fn returns the duration of the src if known.
+func WithAudioDuration(fn func(src string) (time.Duration, bool)) ValidateFunc {
+	return func(cfg *ValidateConfig) {
+		cfg.AudioDuration = fn
+	}
+}
+
+// WithMaxDuration validates the estimated duration of the speech, see Node.Estimate.
+func WithMaxDuration(d time.Duration) ValidateFunc {
+	return func(cfg *ValidateConfig) {
+		cfg.MaxDuration = d
+	}
+}
+
+// WithWarnings passes speech that Alexa may not support to fn instead of failing validation,
+// e.g. a SpeechconError for an interjection not in the speechcons of the locale.
+func WithWarnings(fn func(err error)) ValidateFunc {
+	return func(cfg *ValidateConfig) {
+		cfg.Warn = fn
+	}
+}
+
+// Validate parses and validates SSML speech, see Node.Validate.
+func Validate(speech string, opts ...ValidateFunc) error {
+	n, err := Parse(speech)
+	if err != nil {
+		return err
+	}
+
+	return n.Validate(opts...)
+}
+
+// phrasing are the elements allowed within text.
+var phrasing = []string{"break", "emphasis", "prosody", "say-as", "sub", "phoneme", "lang", "w", "mark"}
+
+// allowedChildren are the elements allowed in an element, text-only elements have none.
+var allowedChildren = map[string][]string{
+	"speak": join(phrasing, "p", "s", "voice", "audio", "amazon:domain", "amazon:effect", "amazon:emotion"),
+	"p":     join(phrasing, "s", "voice", "audio", "amazon:domain", "amazon:effect", "amazon:emotion"),
+	"s":     join(phrasing, "voice", "audio", "amazon:effect", "amazon:emotion"),
+
+	"emphasis":       phrasing,
+	"prosody":        join(phrasing, "p", "s", "audio", "amazon:effect"),
+	"lang":           join(phrasing, "p", "s", "voice", "audio", "amazon:effect"),
+	"voice":          join(phrasing, "p", "s", "audio", "amazon:domain"),
+	"amazon:domain":  join(phrasing, "p", "s", "audio"),
+	"amazon:effect":  join(phrasing, "p", "s", "audio"),
+	"amazon:emotion": join(phrasing, "p", "s", "audio"),
+
+	"say-as":  {},
+	"sub":     {},
+	"phoneme": {},
+	"w":       {},
+	"break":   {},
+	"audio":   {},
+	"mark":    {},
+}
+
+func join(base []string, names ...string) []string {
+	return append(append([]string(nil), base...), names...)
+}
+
+// emptyElements have neither text nor children.
+var emptyElements = map[string]bool{"break": true, "audio": true, "mark": true}
+
+// Validate validates the speech against the rules of Alexa: allowed tags and nesting,
+// attribute values, break times of at most 10s, at most MaxAudioTags audio tags with a total of MaxAudioDuration,
+// amazon:emotion only in EmotionLocales, voices of the catalog only in their locales and languages
+// (see VoicesFor, voices not in the catalog are not checked), at most MaxSpeechCharacters and the duration
+// given with WithMaxDuration. Interjections not in the Speechcons of the locale are warnings, see WithWarnings.
+func (n *Node) Validate(opts ...ValidateFunc) error {
+	var cfg ValidateConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	v := &validator{cfg: cfg}
+	v.node(n, nil)
+
+	if v.audios > MaxAudioTags {
+		v.fail("audio", fmt.Sprintf("%d audio tags, at most %d allowed", v.audios, MaxAudioTags))
+	}
+
+	if v.audioDuration > MaxAudioDuration {
+		v.fail("audio", fmt.Sprintf("total duration %s exceeds %s", v.audioDuration, MaxAudioDuration))
+	}
+
+	est := n.Estimate(opts...)
+	if est.Characters > MaxSpeechCharacters {
+		v.fail(n.Name, fmt.Sprintf("%d characters, at most %d allowed", est.Characters, MaxSpeechCharacters))
+	}
+
+	if cfg.MaxDuration > 0 && est.Duration() > cfg.MaxDuration {
+		v.fail(n.Name, fmt.Sprintf("estimated duration %s exceeds %s", est.Duration().Round(time.Second), cfg.MaxDuration))
+	}
+
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	cfg           ValidateConfig
+	errs          []error
+	audios        int
+	audioDuration time.Duration
+}
+
+func (v *validator) fail(elem, reason string) {
+	v.errs = append(v.errs, ValidationError{elem, reason})
+}
+
+// warn reports speech Alexa may not support, see WithWarnings.
+func (v *validator) warn(err error) {
+	if v.cfg.Warn != nil {
+		v.cfg.Warn(err)
+	}
+}
+
+func (v *validator) node(n, parent *Node) {
+	if n.IsText() {
+		if parent != nil && emptyElements[parent.Name] && strings.TrimSpace(n.Text) != "" {
+			v.fail(parent.Name, "must be empty")
+		}
+
+		return
+	}
+
+	if _, ok := allowedChildren[n.Name]; !ok && n.Name != "speak" {
+		v.fail(n.Name, "unsupported tag")
+		return
+	}
+
+	switch {
+	case parent == nil && n.Name != "speak":
+		v.fail(n.Name, "speech must be in <speak> tags")
+	case parent != nil && !oneOf(n.Name, allowedChildren[parent.Name]...):
+		v.fail(n.Name, "not allowed in <"+parent.Name+">")
+	}
+
+	v.attributes(n)
+
+	for _, c := range n.Children {
+		v.node(c, n)
+	}
+}
+
+// attributes validates the attributes with a Builder.
+func (v *validator) attributes(n *Node) {
+	b := New()
+
+	switch n.Name {
+	case "break":
+		b.Break(BreakStrength(n.Attr("strength")), n.Attr("time"))
+	case "emphasis":
+		b.Emphasis(EmphasisLevel(n.Attr("level")), nil)
+	case "prosody":
+		b.Prosody(ProsodyRate(n.Attr("rate")), ProsodyPitch(n.Attr("pitch")), ProsodyVolume(n.Attr("volume")), nil)
+	case "say-as":
+		b.SayAs(SayAsInterpretAs(n.Attr("interpret-as")), n.Attr("format"), "")
+
+		word := n.innerText()
+		if n.Attr("interpret-as") == string(SayAsInterpretAsInterjection) && v.cfg.Locale != "" &&
+			!IsSpeechcon(v.cfg.Locale, word) {
+			v.warn(SpeechconError{v.cfg.Locale, word})
+		}
+	case "sub":
+		b.Sub(n.Attr("alias"), "")
+	case "phoneme":
+		b.Phoneme(PhonemeAlphabet(n.Attr("alphabet")), n.Attr("ph"), "")
+	case "lang":
+		b.Lang(n.Attr("xml:lang"), nil)
+	case "voice":
+		v.voice(n)
+	case "w":
+		b.W(AmazonRole(n.Attr("role")), "")
+	case "amazon:domain":
+		b.Domain(AmazonDomain(n.Attr("name")), nil)
+	case "amazon:effect":
+		b.Effect(AmazonEffect(n.Attr("name")), nil)
+	case "amazon:emotion":
+		b.Emotion(AmazonEmotion(n.Attr("name")), AmazonEmotionIntensity(n.Attr("intensity")), nil)
+
+		if v.cfg.Locale != "" && !oneOf(v.cfg.Locale, EmotionLocales...) {
+			v.fail(n.Name, "not supported in locale "+v.cfg.Locale)
+		}
+	case "audio":
+		src := n.Attr("src")
+		b.Audio(src)
+
+		v.audios++
+
+		if v.cfg.AudioDuration != nil {
+			if d, ok := v.cfg.AudioDuration(src); ok {
+				v.audioDuration += d
+			}
+		}
+	}
+
+	v.errs = append(v.errs, b.errs...)
+}
+
+// voice validates the voice is accepted in the locale and speaks the languages of its lang tags.
+//
+// Voices not in the catalog are not checked, see RegisterVoice.
+func (v *validator) voice(n *Node) {
+	name := n.Attr("name")
+	if name == "" {
+		v.errs = append(v.errs, AttributeError{n.Name, "name", name})
+		return
+	}
+
+	voice, ok := VoiceInfo(PollyVoice(name))
+	if !ok {
+		return
+	}
+
+	if v.cfg.Locale != "" && !voice.AcceptedIn(v.cfg.Locale) {
+		v.fail(n.Name, "voice "+name+" not supported in locale "+v.cfg.Locale)
+	}
+
+	for _, c := range n.Children {
+		if lang := c.Attr("xml:lang"); c.Name == "lang" && lang != "" && !voice.Speaks(lang) {
+			v.fail(n.Name, "voice "+name+" does not speak "+lang)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ssml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	speech := `<speak>Hi <amazon:emotion name="excited" intensity="high">you</amazon:emotion>` +
+		`<lang xml:lang="de-DE">Tom &amp; Jerry</lang><break time="1s"/></speak>`
+
+	n, err := Parse(speech)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if n.Name != "speak" || len(n.Children) != 4 {
+		t.Fatalf("Parse() = %v, want speak with 4 children", n)
+	}
+	if got := n.Children[1].Attr("intensity"); got != "high" {
+		t.Errorf("Attr() = %v, want high", got)
+	}
+	if got := n.Children[2].Attr("xml:lang"); got != "de-DE" {
+		t.Errorf("Attr() = %v, want de-DE", got)
+	}
+	if got := n.Children[2].Children[0].Text; got != "Tom & Jerry" {
+		t.Errorf("Text = %v, want Tom & Jerry", got)
+	}
+	if got := n.String(); got != speech {
+		t.Errorf("String() = %v, want %v", got, speech)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		speech string
+	}{
+		{"NoSpeak", `<p>Hi</p>`},
+		{"Text", `Hi`},
+		{"Unclosed", `<speak><p>Hi</speak>`},
+		{"Ampersand", `<speak>Tom & Jerry</speak>`},
+		{"TwoRoots", `<speak>a</speak><speak>b</speak>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.speech); err == nil {
+				t.Errorf("Parse() expected error for %v", tt.speech)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	audio := `<audio src="soundbank://soundlibrary/animals/amzn_sfx_dog_med_bark_1x_01"/>`
+	duration := WithAudioDuration(func(string) (time.Duration, bool) { return time.Minute, true })
+
+	tests := []struct {
+		name   string
+		speech string
+		opts   []ValidateFunc
+		want   string
+	}{
+		{"Valid", `<speak><p><s>Hi <emphasis level="strong">you</emphasis></s></p><break time="10s"/></speak>`, nil, ""},
+		{"UnknownTag", `<speak><blink>Hi</blink></speak>`, nil, "ssml: <blink>: unsupported tag"},
+		{"Nesting", `<speak><s><p>Hi</p></s></speak>`, nil, "ssml: <p>: not allowed in <s>"},
+		{"NestedSpeak", `<speak><speak>Hi</speak></speak>`, nil, "ssml: <speak>: not allowed in <speak>"},
+		{"TextOnly", `<speak><say-as interpret-as="digits"><break/>1</say-as></speak>`, nil, "ssml: <break>: not allowed in <say-as>"},
+		{"BreakTime", `<speak><break time="11s"/></speak>`, nil, "ssml: invalid value '11s' of attribute 'time' in <break>"},
+		{"BreakText", `<speak><break>x</break></speak>`, nil, "ssml: <break>: must be empty"},
+		{"AudioTags", `<speak>` + strings.Repeat(audio, 6) + `</speak>`, nil, "ssml: <audio>: 6 audio tags, at most 5 allowed"},
+		{"AudioDuration", `<speak>` + strings.Repeat(audio, 5) + `</speak>`, []ValidateFunc{duration},
+			"ssml: <audio>: total duration 5m0s exceeds 4m0s"},
+		{"Emotion", `<speak><amazon:emotion name="excited" intensity="low">Hi</amazon:emotion></speak>`,
+			[]ValidateFunc{ForLocale("de-DE")}, "ssml: <amazon:emotion>: not supported in locale de-DE"},
+		{"EmotionLocale", `<speak><amazon:emotion name="excited" intensity="low">Hi</amazon:emotion></speak>`,
+			[]ValidateFunc{ForLocale("en-US")}, ""},
+		{"UnknownVoice", `<speak><voice name="Bob">Hi</voice></speak>`, []ValidateFunc{ForLocale("en-US")}, ""},
+		{"VoiceName", `<speak><voice>Hi</voice></speak>`, nil, "ssml: invalid value '' of attribute 'name' in <voice>"},
+		{"VoiceLocale", `<speak><voice name="Hans">Hi</voice></speak>`,
+			[]ValidateFunc{ForLocale("en-US")}, "ssml: <voice>: voice Hans not supported in locale en-US"},
+		{"VoiceLang", `<speak><voice name="Hans"><lang xml:lang="fr-FR">Salut</lang></voice></speak>`, nil,
+			"ssml: <voice>: voice Hans does not speak fr-FR"},
+		{"VoiceEnglish", `<speak><voice name="Brian"><lang xml:lang="en-GB">Hi</lang></voice></speak>`,
+			[]ValidateFunc{ForLocale("en-US")}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.speech, tt.opts...)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_ErrorType(t *testing.T) {
+	err := Validate(`<speak><blink/></speak>`)
+
+	var vErr ValidationError
+	if !errors.As(err, &vErr) || vErr.Element != "blink" {
+		t.Errorf("Validate() error = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,53 @@
+package ssml
+
+import "strings"
+
+// Sound is an audio file of the Alexa Skills Kit sound library.
+// https://developer.amazon.com/en-US/docs/alexa/custom-skills/ask-soundlibrary.html
+type Sound string
+
+// Sounds of the sound library.
+const (
+	SoundCatMeow          Sound = "soundbank://soundlibrary/animals/amzn_sfx_cat_meow_1x_01"
+	SoundDogBark          Sound = "soundbank://soundlibrary/animals/amzn_sfx_dog_med_bark_1x_01"
+	SoundRoosterCrow      Sound = "soundbank://soundlibrary/animals/amzn_sfx_rooster_crow_01"
+	SoundCarAccelerate    Sound = "soundbank://soundlibrary/transportation/amzn_sfx_car_accelerate_01"
+	SoundCrowdApplause    Sound = "soundbank://soundlibrary/human/amzn_sfx_crowd_applause_01"
+	SoundCrowdCheer       Sound = "soundbank://soundlibrary/human/amzn_sfx_large_crowd_cheer_01"
+	SoundDrumComedy       Sound = "soundbank://soundlibrary/musical/amzn_sfx_drum_comedy_01"
+	SoundTrumpetBugle     Sound = "soundbank://soundlibrary/musical/amzn_sfx_trumpet_bugle_03"
+	SoundThunderRumble    Sound = "soundbank://soundlibrary/nature/amzn_sfx_thunder_rumble_01"
+	SoundDoorbell         Sound = "soundbank://soundlibrary/home/amzn_sfx_doorbell_01"
+	SoundMagicBlast       Sound = "soundbank://soundlibrary/magic/amzn_sfx_magic_blast_1x_01"
+	SoundGameshowIntro    Sound = "soundbank://soundlibrary/ui/gameshow/amzn_ui_sfx_gameshow_intro_01"
+	SoundGameshowOutro    Sound = "soundbank://soundlibrary/ui/gameshow/amzn_ui_sfx_gameshow_outro_01"
+	SoundGameshowPositive Sound = "soundbank://soundlibrary/ui/gameshow/amzn_ui_sfx_gameshow_positive_response_01"
+	SoundGameshowNegative Sound = "soundbank://soundlibrary/ui/gameshow/amzn_ui_sfx_gameshow_negative_response_01"
+)
+
+// Sounds are the sounds of the catalog.
+var Sounds = []Sound{
+	SoundCatMeow, SoundDogBark, SoundRoosterCrow, SoundCarAccelerate, SoundCrowdApplause, SoundCrowdCheer,
+	SoundDrumComedy, SoundTrumpetBugle, SoundThunderRumble, SoundDoorbell, SoundMagicBlast,
+	SoundGameshowIntro, SoundGameshowOutro, SoundGameshowPositive, SoundGameshowNegative,
+}
+
+// Category returns the category of the sound, e.g. "animals" or "ui/gameshow".
+func (s Sound) Category() string {
+	path := strings.TrimPrefix(string(s), "soundbank://soundlibrary/")
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+// UseSound plays a sound of the sound library.
+func UseSound(sound Sound) string {
+	return UseAudio(string(sound))
+}
+
+// Sound appends a sound of the sound library.
+func (b *Builder) Sound(sound Sound) *Builder {
+	return b.Audio(string(sound))
+}
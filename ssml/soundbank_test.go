@@ -0,0 +1,20 @@
+package ssml
+
+import "testing"
+
+func TestSound(t *testing.T) {
+	if got := SoundDogBark.Category(); got != "animals" {
+		t.Errorf("Category() = %v, want animals", got)
+	}
+	if got := SoundGameshowIntro.Category(); got != "ui/gameshow" {
+		t.Errorf("Category() = %v, want ui/gameshow", got)
+	}
+
+	want := `<speak><audio src="` + string(SoundDoorbell) + `"/></speak>`
+	if got := New().Sound(SoundDoorbell).String(); got != want {
+		t.Errorf("Sound() = %v, want %v", got, want)
+	}
+	if got := UseSound(SoundDoorbell); got != `<audio src="`+string(SoundDoorbell)+`"/>` {
+		t.Errorf("UseSound() = %v", got)
+	}
+}
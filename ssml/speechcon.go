@@ -0,0 +1,157 @@
+package ssml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SpeechconError defines an interjection that is no speechcon of the locale.
+type SpeechconError struct {
+	Locale string
+	Word   string
+}
+
+// Error returns a string of the error.
+func (e SpeechconError) Error() string {
+	return fmt.Sprintf("ssml: '%s' is no speechcon in locale %s", e.Word, e.Locale)
+}
+
+var (
+	speechconsMu sync.RWMutex
+	// speechcons are the speechcons by locale or language.
+	// https://developer.amazon.com/en-US/docs/alexa/custom-skills/speechcon-reference-interjections-english-us.html
+	speechcons = map[string][]string{
+		"en": {
+			"abracadabra", "achoo", "ahem", "ahoy", "all righty", "aloha", "aooga", "argh", "arrivederci", "as if",
+			"as you wish", "au revoir", "aw man", "awesome", "baa", "bada bing bada boom", "bah humbug", "bam", "bang",
+			"batter up", "bazinga", "beep beep", "bingo", "blah", "blarg", "blast", "boing", "bon appetit", "bon voyage",
+			"bonjour", "boo", "boo hoo", "boom", "booya", "bravo", "bummer", "caw", "cha ching", "checkmate", "cheer up",
+			"cheerio", "cheers", "chirp", "choo choo", "clank", "click clack", "cock a doodle doo", "coo", "cowabunga",
+			"d'oh", "darn", "ding dong", "ditto", "dot dot dot", "duh", "dum", "dun dun dun", "dynomite", "eek", "eureka",
+			"fancy that", "geronimo", "giddy up", "good grief", "good luck", "good riddance", "gotcha", "great scott",
+			"heads up", "hear hear", "hip hip hooray", "hiss", "hmm", "honk", "howdy", "hurrah", "hurray", "huzzah",
+			"jeepers creepers", "jiminy cricket", "jinx", "just kidding", "kablam", "kaboom", "kaching", "kapow", "katchow",
+			"kazaam", "kerbam", "kerboom", "kerching", "kerchoo", "kerflop", "kerplop", "kerplunk", "kerpow", "kersplat",
+			"kerthump", "knock knock", "le sigh", "look out", "mamma mia", "man overboard", "mazel tov", "meow", "merci",
+			"moo", "nanu nanu", "neener neener", "no way", "now now", "oh boy", "oh brother", "oh dear", "oh my",
+			"oh my gosh", "oh no", "oh snap", "oh yeah", "oink", "okey dokey", "oof", "ooh la la", "oops", "open sesame",
+			"ouch", "ow", "oww", "oy vey", "phew", "phooey", "ping", "plop", "pop", "pow", "quack", "read 'em and weep",
+			"ribbit", "righto", "roger", "ruh roh", "shazam", "shh", "shucks", "sigh", "snap", "sniff", "splash", "sploosh",
+			"spoiler alert", "squee", "swish", "swoosh", "ta da", "ta ta", "tee hee", "there there", "thump",
+			"tick tick tick", "tick tock", "touche", "tsk tsk", "tut tut", "twiddle dee dee", "uh huh", "uh oh", "um",
+			"voila", "vroom", "wah wah", "wahoo", "watch out", "way to go", "well done", "well well", "wham", "whammo",
+			"whee", "whew", "whoops a daisy", "whoosh", "woo hoo", "woof", "wow", "wowza", "wowzer", "yadda yadda yadda",
+			"yay", "yikes", "yippee", "yoink", "yoo hoo", "you bet", "yowza", "yowzer", "yuck", "yum", "zap", "zing",
+			"zoinks",
+		},
+		"de": {
+			"aha", "ach du liebe zeit", "alles klar", "bingo", "bravo", "donnerwetter", "geschafft", "hurra",
+			"juhu", "mahlzeit", "na und", "oh mann", "oje", "prost", "super", "toll", "tschüss", "uff", "wow",
+		},
+		"fr": {
+			"bah", "bingo", "bravo", "chut", "d'accord", "hein", "hourra", "oh là là", "ouf", "oups", "super",
+			"voilà", "youpi",
+		},
+		"it": {
+			"ahi", "bravo", "caspita", "ecco", "evviva", "magari", "mamma mia", "uffa", "urrà",
+		},
+		"es": {
+			"ajá", "bingo", "bravo", "caramba", "genial", "hurra", "ojalá", "olé", "uf", "vaya",
+		},
+	}
+	// completeSpeechcons are the locales or languages with the complete list of speechcons,
+	// Interjection only rejects words of these.
+	completeSpeechcons = map[string]bool{"en": true}
+)
+
+// RegisterSpeechcons adds speechcons of a locale (e.g. "en-GB") or language (e.g. "en").
+func RegisterSpeechcons(locale string, words ...string) {
+	speechconsMu.Lock()
+	defer speechconsMu.Unlock()
+
+	for _, w := range words {
+		speechcons[locale] = append(speechcons[locale], normalizeSpeechcon(w))
+	}
+}
+
+// Speechcons returns the sorted speechcons of the locale, including those of its language.
+func Speechcons(locale string) []string {
+	speechconsMu.RLock()
+	defer speechconsMu.RUnlock()
+
+	words := append([]string(nil), speechcons[locale]...)
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		words = append(words, speechcons[lang]...)
+	}
+
+	sort.Strings(words)
+
+	return words
+}
+
+// IsSpeechcon returns true if the word is a speechcon of the locale, ignoring case and trailing punctuation.
+func IsSpeechcon(locale, word string) bool {
+	return oneOf(normalizeSpeechcon(word), Speechcons(locale)...)
+}
+
+// CompleteSpeechcons returns true if the list of speechcons of the locale or its language is complete.
+func CompleteSpeechcons(locale string) bool {
+	speechconsMu.RLock()
+	defer speechconsMu.RUnlock()
+
+	lang, _, _ := strings.Cut(locale, "-")
+
+	return completeSpeechcons[locale] || completeSpeechcons[lang]
+}
+
+// checkSpeechcon returns a SpeechconError if the word is no speechcon of a locale with a complete list,
+// words missing in incomplete lists are passed to the warning function, see WithWarnings.
+func checkSpeechcon(locale, word string, opts []ValidateFunc) error {
+	if IsSpeechcon(locale, word) {
+		return nil
+	}
+
+	if CompleteSpeechcons(locale) {
+		return SpeechconError{locale, word}
+	}
+
+	var cfg ValidateConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.Warn != nil {
+		cfg.Warn(SpeechconError{locale, word})
+	}
+
+	return nil
+}
+
+func normalizeSpeechcon(word string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(word), ".,!?"))
+}
+
+// Interjection returns the word as speechcon of the locale or a SpeechconError.
+// <say-as interpret-as="interjection">bingo</say-as>.
+//
+// Only locales with a complete list of speechcons (see CompleteSpeechcons) return errors,
+// other words are reported as warnings, see WithWarnings.
+func Interjection(locale, word string, opts ...ValidateFunc) (string, error) {
+	if err := checkSpeechcon(locale, word, opts); err != nil {
+		return "", err
+	}
+
+	return SayAs(SayAsInterpretAsInterjection, "", Escape(word)), nil
+}
+
+// Interjection appends the word as speechcon of the locale, other words are recorded as SpeechconError
+// in locales with a complete list of speechcons and reported as warnings otherwise, see Interjection.
+func (b *Builder) Interjection(locale, word string, opts ...ValidateFunc) *Builder {
+	if err := checkSpeechcon(locale, word, opts); err != nil {
+		b.errs = append(b.errs, err)
+	}
+
+	return b.SayAs(SayAsInterpretAsInterjection, "", word)
+}
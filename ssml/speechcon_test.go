@@ -0,0 +1,83 @@
+package ssml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterjection(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		word    string
+		want    string
+		wantErr bool
+	}{
+		{"English", "en-US", "Bingo!", `<say-as interpret-as="interjection">Bingo!</say-as>`, false},
+		{"Phrase", "en-US", "Hip hip hooray!", `<say-as interpret-as="interjection">Hip hip hooray!</say-as>`, false},
+		{"Apostrophe", "en-GB", "d'oh", `<say-as interpret-as="interjection">d&apos;oh</say-as>`, false},
+		{"German", "de-DE", "Juhu", `<say-as interpret-as="interjection">Juhu</say-as>`, false},
+		{"Unknown", "en-US", "juhu", "", true},
+		{"UnknownIncomplete", "de-DE", "bazinga", `<say-as interpret-as="interjection">bazinga</say-as>`, false},
+		{"NoSpeechcons", "ja-JP", "wow", `<say-as interpret-as="interjection">wow</say-as>`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Interjection(tt.locale, tt.word)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Interjection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Interjection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterSpeechcons(t *testing.T) {
+	RegisterSpeechcons("en-IN", "Arre Wah!")
+
+	if !IsSpeechcon("en-IN", "arre wah") || IsSpeechcon("en-US", "arre wah") {
+		t.Errorf("IsSpeechcon() = %v", Speechcons("en-IN"))
+	}
+}
+
+func TestBuilder_Interjection(t *testing.T) {
+	var sErr SpeechconError
+
+	err := New().Interjection("en-US", "juhu").Err()
+	if !errors.As(err, &sErr) || sErr.Word != "juhu" {
+		t.Errorf("Err() = %v, want SpeechconError", err)
+	}
+
+	var warnings []error
+	warn := WithWarnings(func(err error) { warnings = append(warnings, err) })
+
+	if err := New().Interjection("fr-FR", "bazinga", warn).Err(); err != nil {
+		t.Errorf("Err() = %v, want warning", err)
+	}
+	if _, err := Interjection("it-IT", "bazinga", warn); err != nil {
+		t.Errorf("Interjection() error = %v, want warning", err)
+	}
+	if len(warnings) != 2 || warnings[0] != (SpeechconError{"fr-FR", "bazinga"}) {
+		t.Errorf("Interjection() warnings = %v", warnings)
+	}
+
+	warnings = nil
+
+	if err := Validate(`<speak><say-as interpret-as="interjection">bazinga</say-as></speak>`, ForLocale("fr-FR"), warn); err != nil {
+		t.Errorf("Validate() error = %v, want warning", err)
+	}
+	if len(warnings) != 1 || warnings[0] != (SpeechconError{"fr-FR", "bazinga"}) {
+		t.Errorf("Validate() warnings = %v", warnings)
+	}
+	if err := Validate(`<speak><say-as interpret-as="interjection">voilà</say-as></speak>`, ForLocale("fr-FR")); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestCompleteSpeechcons(t *testing.T) {
+	if !CompleteSpeechcons("en-AU") || CompleteSpeechcons("de-DE") || CompleteSpeechcons("ja-JP") {
+		t.Error("CompleteSpeechcons() want only English complete")
+	}
+}
@@ -85,19 +85,17 @@ const (
 )
 
 // Break adds a break to speech.
-// only use `strength` or `time`, not both.
+// only `strength` or `time` is used, the time if both are given.
 // time in `ms` or `s` - may not exceed 10s.
 func Break(strength BreakStrength, time string) string {
-	params := []string{""}
-	if strength != "" {
-		params = append(params, `strength="`+string(strength)+`"`)
-	}
-
-	if time != "" {
-		params = append(params, `time="`+time+`"`)
+	switch {
+	case time != "":
+		return `<break time="` + time + `"/>`
+	case strength != "":
+		return `<break strength="` + string(strength) + `"/>`
+	default:
+		return `<break/>`
 	}
-
-	return fmt.Sprintf(`<break%s/>`, strings.Join(params, " "))
 }
 
 // EmphasisLevel is the level of emphasis.
@@ -146,7 +144,7 @@ const (
 // Phoneme pronounces the given text based on the provided alphabet and characters.
 // <phoneme alphabet="ipa" ph="pɪˈkɑːn">pecan</phoneme>.
 func Phoneme(alphabet PhonemeAlphabet, ph, text string) string {
-	return `<phoneme alphabet="` + string(alphabet) + `" ph="` + ph + `">` + text + `</phoneme>`
+	return `<phoneme alphabet="` + string(alphabet) + `" ph="` + Escape(ph) + `">` + text + `</phoneme>`
 }
 
 // ProsodyRate defines the speed of the voice. Can be provided in %: 100% is normal speed.
@@ -212,8 +210,12 @@ type SayAsInterpretAs string
 const (
 	// SayAsInterpretAsCharacters spells out each letter.
 	SayAsInterpretAsCharacters SayAsInterpretAs = "characters"
+	// SayAsInterpretAsSpellOut spells out each letter, same as characters.
+	SayAsInterpretAsSpellOut SayAsInterpretAs = "spell-out"
 	// SayAsInterpretAsCardinal interprets the value as a cardinal number.
 	SayAsInterpretAsCardinal SayAsInterpretAs = "cardinal"
+	// SayAsInterpretAsNumber interprets the value as a cardinal number, same as cardinal.
+	SayAsInterpretAsNumber SayAsInterpretAs = "number"
 	// SayAsInterpretAsOrdinal interprets the value as an ordinal number.
 	SayAsInterpretAsOrdinal SayAsInterpretAs = "ordinal"
 	// SayAsInterpretAsDigits spells each digit separately.
@@ -260,7 +262,7 @@ func Speak(text string) string {
 // <sub alias="aluminum">Al</sub>
 // <sub alias="if I remember correctly">IIRC</sub>.
 func Sub(alias, text string) string {
-	return `<sub alias="` + alias + `">` + text + `</sub>`
+	return `<sub alias="` + Escape(alias) + `">` + text + `</sub>`
 }
 
 // PollyVoice defines the voice name for speech.
@@ -271,6 +273,7 @@ const (
 	// US : Ivy, Joanna, Joey, Justin, Kendra, Kimberly, Matthew, Salli.
 	USVoiceIvy      PollyVoice = "Ivy"
 	USVoiceJoanna   PollyVoice = "Joanna"
+	USVoiceJoey     PollyVoice = "Joey"
 	USVoiceJustin   PollyVoice = "Justin"
 	USVoiceKendra   PollyVoice = "Kendra"
 	USVoiceKimberly PollyVoice = "Kimberly"
@@ -278,7 +281,7 @@ const (
 	USVoiceSalli    PollyVoice = "Salli"
 	// AU : Nicole, Russell.
 	AUVoiceNicole PollyVoice = "Nicole"
-	AUVoiceRussel PollyVoice = "Russel"
+	AUVoiceRussel PollyVoice = "Russell"
 	// GB : Amy, Brian, Emma.
 	GBVoiceAmy   PollyVoice = "Amy"
 	GBVoiceBrian PollyVoice = "Brian"
@@ -303,7 +306,7 @@ const (
 	ITVoiceGiorgio PollyVoice = "Giorgio"
 	ITVoiceBianca  PollyVoice = "Bianca"
 	// JP : Mizuki, Takumi.
-	JPVoiceMizuki PollyVoice = "Mitzuki"
+	JPVoiceMizuki PollyVoice = "Mizuki"
 	JPVoiceTakumi PollyVoice = "Takumi"
 	// BR : Vitoria, Camila, Ricardo.
 	BRVoiceVitoria PollyVoice = "Vitoria"
@@ -322,11 +325,16 @@ const (
 )
 
 // UseVoice wraps text in tags using a specific voice.
+//
+// The voice is not checked here, see UseVoiceChecked. Validate reports voices of the catalog not accepted
+// in the locale, see VoicesFor.
 func UseVoice(voice PollyVoice, text string) string {
 	return `<voice name="` + string(voice) + `">` + text + `</voice>`
 }
 
 // UseVoiceLang wraps text in tags using a specific voice and language.
+//
+// Validate reports a language a voice of the catalog does not speak, see Voice.Speaks and UseVoiceLangChecked.
 func UseVoiceLang(voice PollyVoice, language, text string) string {
 	return `<voice name="` + string(voice) + `"><lang xml:lang="` + language + `">` + text + `</lang></voice>`
 }
@@ -2,7 +2,6 @@ package ssml
 
 import (
 	"fmt"
-	"github.com/drpsychick/go-alexa-lambda"
 	"testing"
 )
 
@@ -19,7 +18,7 @@ func TestBreak(t *testing.T) {
 		{"Break", args{}, "<break/>"},
 		{"BreakStrength", args{BreakStrengthWeak, ""}, `<break strength="weak"/>`},
 		{"BreakTime", args{"", "1s"}, `<break time="1s"/>`},
-		{"BreakText", args{BreakStrengthMedium, "5s"}, `<break strength="medium" time="5s"/>`},
+		{"BreakText", args{BreakStrengthMedium, "5s"}, `<break time="5s"/>`},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -377,9 +376,9 @@ func TestUseVoiceLang(t *testing.T) {
 		want string
 	}{
 		{"VoiceLangNoArgs", args{}, `<voice name=""><lang xml:lang=""></lang></voice>`},
-		{"VoiceLang", args{DEVoiceMarlene, string(alexa.LocaleGerman), "ich heisse Marlene"},
+		{"VoiceLang", args{DEVoiceMarlene, "de-DE", "ich heisse Marlene"},
 			fmt.Sprintf(`<voice name="%s"><lang xml:lang="%s">%s</lang></voice>`,
-				string(DEVoiceMarlene), string(alexa.LocaleGerman), "ich heisse Marlene",
+				string(DEVoiceMarlene), "de-DE", "ich heisse Marlene",
 			),
 		},
 	}
@@ -0,0 +1,159 @@
+package ssml
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ToPlainText converts SSML speech to plain text, e.g. for the card of a response.
+//
+// Tags are stripped, sub aliases are expanded, say-as digits, characters, telephone numbers and dates are
+// rendered as text, expletives are bleeped and audio is dropped. Paragraphs are separated by an empty line.
+func ToPlainText(speech string) (string, error) {
+	n, err := Parse(speech)
+	if err != nil {
+		return "", err
+	}
+
+	return n.PlainText(), nil
+}
+
+// PlainText returns the text of the node, see ToPlainText.
+func (n *Node) PlainText() string {
+	var sb strings.Builder
+	n.plainText(&sb)
+
+	paragraphs := strings.Split(sb.String(), "\n")
+
+	lines := make([]string, 0, len(paragraphs))
+	for _, p := range paragraphs {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			lines = append(lines, tidy(p))
+		}
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+func (n *Node) plainText(sb *strings.Builder) {
+	if n.IsText() {
+		sb.WriteString(n.Text)
+		return
+	}
+
+	switch n.Name {
+	case "audio", "mark":
+		return
+	case "break":
+		sb.WriteString(" ")
+		return
+	case "sub":
+		sb.WriteString(n.Attr("alias"))
+		return
+	case "say-as":
+		sb.WriteString(sayAsText(SayAsInterpretAs(n.Attr("interpret-as")), n.Attr("format"), n.innerText()))
+		return
+	case "p":
+		sb.WriteString("\n")
+		defer sb.WriteString("\n")
+	case "s":
+		defer sb.WriteString(" ")
+	}
+
+	for _, c := range n.Children {
+		c.plainText(sb)
+	}
+}
+
+func (n *Node) innerText() string {
+	var sb strings.Builder
+	for _, c := range n.Children {
+		c.plainText(&sb)
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// tidy removes spaces before punctuation left by stripped tags.
+func tidy(s string) string {
+	for _, p := range []string{".", ",", "!", "?", ";", ":"} {
+		s = strings.ReplaceAll(s, " "+p, p)
+	}
+
+	return s
+}
+
+func sayAsText(interpretAs SayAsInterpretAs, format, text string) string {
+	switch interpretAs {
+	case SayAsInterpretAsDigits, SayAsInterpretAsCharacters:
+		return spell(text)
+	case SayAsInterpretAsTelephone:
+		return telephone(text)
+	case SayAsInterpretAsDate:
+		return date(format, text)
+	case SayAsInterpretAsExpletive:
+		return strings.Repeat("*", len([]rune(text)))
+	default:
+		return text
+	}
+}
+
+// spell separates each letter or digit by a space.
+func spell(text string) string {
+	var parts []string
+
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			parts = append(parts, string(r))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// telephone formats 7 and 10 digit numbers like 555-1212 and 202-555-1212, keeping an extension.
+func telephone(text string) string {
+	number, ext, hasExt := strings.Cut(text, "x")
+
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, number)
+
+	switch len(digits) {
+	case 7:
+		number = digits[:3] + "-" + digits[3:]
+	case 10:
+		number = digits[:3] + "-" + digits[3:6] + "-" + digits[6:]
+	}
+
+	if hasExt {
+		return number + " x" + ext
+	}
+
+	return number
+}
+
+// date formats a date like "20240301" or "????0301" as "2024-03-01" or "03-01", unknown parts are omitted.
+func date(format, text string) string {
+	if format == "" {
+		format = "ymd"
+	}
+
+	if len(text) != 8 || format != "ymd" {
+		return text
+	}
+
+	var parts []string
+
+	for _, p := range []string{text[:4], text[4:6], text[6:]} {
+		if !strings.Contains(p, "?") {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,44 @@
+package ssml
+
+import "testing"
+
+func TestToPlainText(t *testing.T) {
+	tests := []struct {
+		name   string
+		speech string
+		want   string
+	}{
+		{"Text", "<speak>Hello World</speak>", "Hello World"},
+		{"Escaped", "<speak>Tom &amp; Jerry</speak>", "Tom & Jerry"},
+		{"Sentences", "<speak><s>Hello</s><s>World</s></speak>", "Hello World"},
+		{"Paragraphs", "<speak><p>Hello</p><p>World <break time=\"1s\"/>again</p></speak>", "Hello\n\nWorld again"},
+		{"Audio", "<speak>Hello <audio src=\"soundbank://x\"/>.</speak>", "Hello."},
+		{"Sub", "<speak><sub alias=\"aluminium\">Al</sub> is light</speak>", "aluminium is light"},
+		{"Digits", "<speak><say-as interpret-as=\"digits\">123</say-as></speak>", "1 2 3"},
+		{"Characters", "<speak><say-as interpret-as=\"characters\">abc</say-as></speak>", "a b c"},
+		{"Telephone", "<speak><say-as interpret-as=\"telephone\">2025551212</say-as></speak>", "202-555-1212"},
+		{"TelephoneShort", "<speak><say-as interpret-as=\"telephone\">5551212 x12</say-as></speak>", "555-1212 x12"},
+		{"Date", "<speak><say-as interpret-as=\"date\">20240301</say-as></speak>", "2024-03-01"},
+		{"DateUnknownYear", "<speak><say-as interpret-as=\"date\">????0301</say-as></speak>", "03-01"},
+		{"DateFormat", "<speak><say-as interpret-as=\"date\" format=\"md\">0301</say-as></speak>", "0301"},
+		{"Expletive", "<speak><say-as interpret-as=\"expletive\">darn</say-as></speak>", "****"},
+		{"Nested", "<speak><prosody rate=\"slow\"><emphasis>Wow</emphasis> , ok</prosody></speak>", "Wow, ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToPlainText(tt.speech)
+			if err != nil {
+				t.Fatalf("ToPlainText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToPlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToPlainText_Invalid(t *testing.T) {
+	if _, err := ToPlainText("Hello"); err == nil {
+		t.Error("ToPlainText() expected error")
+	}
+}
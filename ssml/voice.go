@@ -0,0 +1,201 @@
+package ssml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// VoiceError defines a voice that is not in the catalog or does not speak the language.
+type VoiceError struct {
+	Voice    PollyVoice
+	Language string
+}
+
+// Error returns a string of the error.
+func (e VoiceError) Error() string {
+	if e.Language == "" {
+		return fmt.Sprintf("ssml: unknown voice '%s'", e.Voice)
+	}
+
+	return fmt.Sprintf("ssml: voice '%s' does not speak %s", e.Voice, e.Language)
+}
+
+// VoiceGender is the gender of a voice.
+type VoiceGender string
+
+const (
+	VoiceGenderFemale VoiceGender = "female" //nolint:revive
+	VoiceGenderMale   VoiceGender = "male"   //nolint:revive
+)
+
+// Voice describes a voice of the catalog.
+type Voice struct {
+	Name   PollyVoice
+	Gender VoiceGender
+	// Languages are the languages spoken by the voice, e.g. "en-IN", "hi-IN".
+	Languages []string
+	// Neural and Standard are the supported engines.
+	Neural   bool
+	Standard bool
+	// Locales are the Alexa locales accepting the voice.
+	Locales []string
+}
+
+// Speaks returns true if the voice speaks the language, e.g. "de-DE".
+func (v Voice) Speaks(language string) bool {
+	return oneOf(language, v.Languages...)
+}
+
+// AcceptedIn returns true if Alexa accepts the voice in the locale.
+func (v Voice) AcceptedIn(locale string) bool {
+	return oneOf(locale, v.Locales...)
+}
+
+// AlexaLocales are the locales of Alexa skills.
+var AlexaLocales = []string{
+	"de-DE", "en-AU", "en-CA", "en-GB", "en-IN", "en-US", "es-ES", "es-MX", "es-US",
+	"fr-CA", "fr-FR", "hi-IN", "it-IT", "ja-JP", "pt-BR",
+}
+
+var (
+	voicesMu sync.RWMutex
+	// voices is the catalog of voices by name.
+	// https://developer.amazon.com/en-US/docs/alexa/custom-skills/speech-synthesis-markup-language-ssml-reference.html#supported-voices
+	voices = catalog(
+		voice(USVoiceIvy, VoiceGenderFemale, true, "en-US"),
+		voice(USVoiceJoanna, VoiceGenderFemale, true, "en-US"),
+		voice(USVoiceJoey, VoiceGenderMale, true, "en-US"),
+		voice(USVoiceJustin, VoiceGenderMale, true, "en-US"),
+		voice(USVoiceKendra, VoiceGenderFemale, true, "en-US"),
+		voice(USVoiceKimberly, VoiceGenderFemale, true, "en-US"),
+		voice(USVoiceMatthew, VoiceGenderMale, true, "en-US"),
+		voice(USVoiceSalli, VoiceGenderFemale, true, "en-US"),
+		voice(AUVoiceNicole, VoiceGenderFemale, false, "en-AU"),
+		voice(AUVoiceRussel, VoiceGenderMale, false, "en-AU"),
+		voice(GBVoiceAmy, VoiceGenderFemale, true, "en-GB"),
+		voice(GBVoiceBrian, VoiceGenderMale, true, "en-GB"),
+		voice(GBVoiceEmma, VoiceGenderFemale, true, "en-GB"),
+		voice(INVoiceAditi, VoiceGenderFemale, false, "en-IN", "hi-IN"),
+		voice(INVoiceRaveena, VoiceGenderFemale, false, "en-IN"),
+		voice(CAVoiceChantal, VoiceGenderFemale, false, "fr-CA"),
+		voice(FRVoiceCeline, VoiceGenderFemale, false, "fr-FR"),
+		voice(FRVoiceLea, VoiceGenderFemale, true, "fr-FR"),
+		voice(FRVoiceMathieu, VoiceGenderMale, false, "fr-FR"),
+		voice(DEVoiceHans, VoiceGenderMale, false, "de-DE"),
+		voice(DEVoiceMarlene, VoiceGenderFemale, false, "de-DE"),
+		voice(DEVoiceVicki, VoiceGenderFemale, true, "de-DE"),
+		voice(ITVoiceCarla, VoiceGenderFemale, false, "it-IT"),
+		voice(ITVoiceGiorgio, VoiceGenderMale, false, "it-IT"),
+		voice(ITVoiceBianca, VoiceGenderFemale, true, "it-IT"),
+		voice(JPVoiceMizuki, VoiceGenderFemale, false, "ja-JP"),
+		voice(JPVoiceTakumi, VoiceGenderMale, true, "ja-JP"),
+		voice(BRVoiceVitoria, VoiceGenderFemale, true, "pt-BR"),
+		voice(BRVoiceCamila, VoiceGenderFemale, true, "pt-BR"),
+		voice(BRVoiceRicardo, VoiceGenderMale, false, "pt-BR"),
+		voice(EsUSVoicePenelope, VoiceGenderFemale, false, "es-US"),
+		voice(EsUSVoiceLupe, VoiceGenderFemale, true, "es-US"),
+		voice(EsUSVoiceMiguel, VoiceGenderMale, false, "es-US"),
+		voice(ESVoiceConchita, VoiceGenderFemale, false, "es-ES"),
+		voice(ESVoiceEnrique, VoiceGenderMale, false, "es-ES"),
+		voice(ESVoiceLucia, VoiceGenderFemale, true, "es-ES"),
+		voice(MXVoiceMia, VoiceGenderFemale, true, "es-MX"),
+	)
+)
+
+// voice returns a voice accepted in all Alexa locales of its languages, e.g. en-GB voices in en-US.
+func voice(name PollyVoice, gender VoiceGender, neural bool, languages ...string) Voice {
+	v := Voice{Name: name, Gender: gender, Languages: languages, Neural: neural, Standard: true}
+
+	for _, locale := range AlexaLocales {
+		for _, lang := range languages {
+			if sameLanguage(locale, lang) {
+				v.Locales = append(v.Locales, locale)
+				break
+			}
+		}
+	}
+
+	return v
+}
+
+func catalog(vs ...Voice) map[PollyVoice]Voice {
+	m := make(map[PollyVoice]Voice, len(vs))
+	for _, v := range vs {
+		m[v.Name] = v
+	}
+
+	return m
+}
+
+func sameLanguage(a, b string) bool {
+	la, _, _ := strings.Cut(a, "-")
+	lb, _, _ := strings.Cut(b, "-")
+
+	return la == lb
+}
+
+// RegisterVoice adds a voice to the catalog or replaces the voice of the same name.
+//
+// Without Locales, the voice is accepted in all Alexa locales of its languages.
+func RegisterVoice(v Voice) {
+	if len(v.Locales) == 0 {
+		v.Locales = voice(v.Name, v.Gender, v.Neural, v.Languages...).Locales
+	}
+
+	voicesMu.Lock()
+	defer voicesMu.Unlock()
+
+	voices[v.Name] = v
+}
+
+// VoiceInfo returns the voice of the catalog.
+func VoiceInfo(name PollyVoice) (Voice, bool) {
+	voicesMu.RLock()
+	defer voicesMu.RUnlock()
+
+	v, ok := voices[name]
+
+	return v, ok
+}
+
+// VoicesFor returns the voices accepted in the locale, e.g. "de-DE", sorted by name.
+func VoicesFor(locale string) []Voice {
+	var vs []Voice
+
+	voicesMu.RLock()
+	for _, v := range voices {
+		if v.AcceptedIn(locale) {
+			vs = append(vs, v)
+		}
+	}
+	voicesMu.RUnlock()
+
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+
+	return vs
+}
+
+// UseVoiceChecked wraps text in tags using a voice of the catalog or returns a VoiceError.
+func UseVoiceChecked(voice PollyVoice, text string) (string, error) {
+	if _, ok := VoiceInfo(voice); !ok {
+		return "", VoiceError{Voice: voice}
+	}
+
+	return UseVoice(voice, text), nil
+}
+
+// UseVoiceLangChecked wraps text in tags using a voice of the catalog speaking the language or returns a VoiceError.
+func UseVoiceLangChecked(voice PollyVoice, language, text string) (string, error) {
+	v, ok := VoiceInfo(voice)
+	if !ok {
+		return "", VoiceError{Voice: voice}
+	}
+
+	if !v.Speaks(language) {
+		return "", VoiceError{voice, language}
+	}
+
+	return UseVoiceLang(voice, language, text), nil
+}
@@ -0,0 +1,116 @@
+package ssml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVoicesFor(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []PollyVoice
+	}{
+		{"de-DE", []PollyVoice{DEVoiceHans, DEVoiceMarlene, DEVoiceVicki}},
+		{"hi-IN", []PollyVoice{INVoiceAditi}},
+		{"ja-JP", []PollyVoice{JPVoiceMizuki, JPVoiceTakumi}},
+		{"xx-XX", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			got := VoicesFor(tt.locale)
+			if len(got) != len(tt.want) {
+				t.Fatalf("VoicesFor() = %v, want %v", got, tt.want)
+			}
+			for i, v := range got {
+				if v.Name != tt.want[i] {
+					t.Errorf("VoicesFor()[%d] = %v, want %v", i, v.Name, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got := len(VoicesFor("en-CA")); got != len(VoicesFor("en-US")) {
+		t.Errorf("VoicesFor(en-CA) = %d voices, want all English voices", got)
+	}
+}
+
+func TestVoiceInfo(t *testing.T) {
+	v, ok := VoiceInfo(INVoiceAditi)
+	if !ok {
+		t.Fatal("VoiceInfo() voice not found")
+	}
+	if v.Gender != VoiceGenderFemale || v.Neural || !v.Standard {
+		t.Errorf("VoiceInfo() = %+v", v)
+	}
+	if !v.Speaks("hi-IN") || v.Speaks("de-DE") {
+		t.Errorf("Speaks() languages = %v", v.Languages)
+	}
+	if !v.AcceptedIn("en-GB") || v.AcceptedIn("de-DE") {
+		t.Errorf("AcceptedIn() locales = %v", v.Locales)
+	}
+
+	if _, ok := VoiceInfo("Mitzuki"); ok {
+		t.Error("VoiceInfo() found misspelled voice")
+	}
+}
+
+func TestUseVoiceChecked(t *testing.T) {
+	got, err := UseVoiceChecked(DEVoiceVicki, "Hallo")
+	if err != nil {
+		t.Fatalf("UseVoiceChecked() error = %v", err)
+	}
+	if want := `<voice name="Vicki">Hallo</voice>`; got != want {
+		t.Errorf("UseVoiceChecked() = %v, want %v", got, want)
+	}
+
+	if _, err := UseVoiceChecked("Mitzuki", "Hallo"); err != (VoiceError{Voice: "Mitzuki"}) {
+		t.Errorf("UseVoiceChecked() error = %v, want unknown voice", err)
+	}
+}
+
+func TestUseVoiceLangChecked(t *testing.T) {
+	got, err := UseVoiceLangChecked(INVoiceAditi, "hi-IN", "Namaste")
+	if err != nil {
+		t.Fatalf("UseVoiceLangChecked() error = %v", err)
+	}
+	if want := `<voice name="Aditi"><lang xml:lang="hi-IN">Namaste</lang></voice>`; got != want {
+		t.Errorf("UseVoiceLangChecked() = %v, want %v", got, want)
+	}
+
+	if _, err := UseVoiceLangChecked(INVoiceAditi, "de-DE", "Hallo"); err != (VoiceError{INVoiceAditi, "de-DE"}) {
+		t.Errorf("UseVoiceLangChecked() error = %v, want language error", err)
+	}
+	if _, err := UseVoiceLangChecked("Mitzuki", "ja-JP", "Konnichiwa"); err != (VoiceError{Voice: "Mitzuki"}) {
+		t.Errorf("UseVoiceLangChecked() error = %v, want unknown voice", err)
+	}
+}
+
+func TestRegisterVoice(t *testing.T) {
+	speech := `<speak><voice name="Daniel">Hallo</voice></speak>`
+	if err := Validate(speech, ForLocale("de-DE")); err != nil {
+		t.Fatalf("Validate() error = %v, unknown voices are not checked", err)
+	}
+
+	if _, err := New().Voice("Daniel", Text("Hallo")).Build(); err != nil {
+		t.Errorf("Build() error = %v, unknown voices are not checked", err)
+	}
+	if _, err := New().VoiceChecked("Daniel", Text("Hallo")).Build(); !errors.Is(err, VoiceError{Voice: "Daniel"}) {
+		t.Errorf("Build() error = %v, want unknown voice", err)
+	}
+	if _, err := New().Voice("", Text("Hallo")).Build(); err == nil {
+		t.Error("Build() expected error for empty voice")
+	}
+
+	RegisterVoice(Voice{Name: "Daniel", Gender: VoiceGenderMale, Languages: []string{"de-DE"}, Neural: true})
+
+	v, ok := VoiceInfo("Daniel")
+	if !ok || !v.AcceptedIn("de-DE") || v.AcceptedIn("en-US") {
+		t.Errorf("VoiceInfo() = %+v, %v", v, ok)
+	}
+	if _, err := New().VoiceChecked("Daniel", Text("Hallo")).Build(); err != nil {
+		t.Errorf("Build() error = %v", err)
+	}
+	if err := Validate(speech, ForLocale("en-US")); err == nil {
+		t.Error("Validate() expected error for voice not accepted in locale")
+	}
+}